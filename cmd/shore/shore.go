@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/Autodesk/shore/pkg/backend/spinnaker"
 	"github.com/Autodesk/shore/pkg/cleanup_command"
 	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/config"
 	"github.com/Autodesk/shore/pkg/project"
 	"github.com/Autodesk/shore/pkg/renderer/jsonnet"
 	"github.com/sirupsen/logrus"
@@ -72,6 +74,7 @@ func init() {
 	// cobra.OnInitialize()
 	fs := afero.NewOsFs()
 	logger = logrus.New()
+	logger.AddHook(config.MaskingHook{})
 
 	commonDependencies := &command.Dependencies{
 		Project:  project.NewShoreProject(fs, logger),
@@ -89,10 +92,21 @@ func init() {
 		"The profile to use. Can also be set by $SHORE_PROFILE environment variable. Priority is: env variable, cli args, default.")
 
 	rootCmd.AddCommand(command.NewProjectCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewImportCommand(commonDependencies))
 	rootCmd.AddCommand(command.NewRenderCommand(commonDependencies))
 	rootCmd.AddCommand(command.NewDiffCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewDriftCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewFmtCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewLintCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewDepsCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewConfigCommand(commonDependencies))
 	rootCmd.AddCommand(command.NewSaveCommand(commonDependencies))
 	rootCmd.AddCommand(command.NewDeleteCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewPruneCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewBackupCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewRestoreCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewHistoryCommand(commonDependencies))
+	rootCmd.AddCommand(command.NewRollbackCommand(commonDependencies))
 	rootCmd.AddCommand(command.NewExecCommand(commonDependencies, "exec"))
 	rootCmd.AddCommand(command.NewTestRemoteCommand(commonDependencies))
 	rootCmd.AddCommand(cleanup_command.NewCleanupCommand(commonDependencies))
@@ -102,7 +116,14 @@ func init() {
 
 func execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, config.MaskSecrets(err.Error()))
+
+		var exitCoder command.ExitCoder
+
+		if errors.As(err, &exitCoder) {
+			os.Exit(exitCoder.ExitCode())
+		}
+
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,169 @@
+package integration_tests
+
+import (
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessfulBackup(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		backupCmd := command.NewBackupCommand(deps)
+		backupCmd.SilenceErrors = true
+		backupCmd.SilenceUsage = true
+		backupCmd.Flags().Set("application", "prune-app")
+		backupCmd.Flags().Set("out", "backups/prune-app")
+
+		// Test
+		err := backupCmd.Execute()
+
+		// Assert
+		manifestContent, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "backups/prune-app", command.BackupManifestFileName))
+		pipelineContent, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "backups/prune-app", "Renamed_Pipeline.json"))
+
+		var manifest command.BackupManifest
+		jsoniter.Unmarshal(manifestContent, &manifest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "prune-app", manifest.Application)
+		assert.Len(t, manifest.Pipelines, 7)
+		assert.Equal(t, command.BackupPipeline{Name: "Renamed Pipeline", ID: "2", File: "Renamed_Pipeline.json"}, manifest.Pipelines[1])
+		assert.Contains(t, string(pipelineContent), `"name": "Renamed Pipeline"`)
+	})
+}
+
+func TestFailedBackupExistingBackup(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		backupPath := path.Join(testPath, "backups/prune-app")
+		_, backupErr := command.Backup(deps, "prune-app", backupPath)
+
+		// Test
+		_, err := command.Backup(deps, "prune-app", backupPath)
+
+		// Assert
+		assert.Nil(t, backupErr)
+		assert.EqualError(t, err, "refusing to overwrite the existing backup in "+backupPath)
+	})
+}
+
+// writeBackup - Writes a backup of `pipelines` (by file name).
+func writeBackup(deps *command.Dependencies, manifest string, pipelines map[string]string) {
+	afero.WriteFile(deps.Project.FS, path.Join(testPath, "backup", command.BackupManifestFileName), []byte(manifest), os.ModePerm)
+
+	for fileName, pipeline := range pipelines {
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "backup", fileName), []byte(pipeline), os.ModePerm)
+	}
+}
+
+func TestSuccessfulRestoreChanges(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		writeBackup(deps, `{"application": "not-exists", "pipelines": [{"name": "Deleted", "id": "1", "file": "Deleted.json"}]}`, map[string]string{
+			"Deleted.json": `{"application": "not-exists", "name": "Deleted", "id": "1"}`,
+		})
+		_, pipelines, loadErr := command.LoadBackup(deps, path.Join(testPath, "backup"))
+
+		restoreCmd := command.NewRestoreCommand(deps)
+		restoreCmd.SilenceErrors = true
+		restoreCmd.SilenceUsage = true
+		restoreCmd.SetArgs([]string{"backup"})
+		restoreCmd.Flags().Set("dry-run", "true")
+
+		// Test
+		changes, err := command.RestoreChanges(deps, pipelines)
+		dryRunErr := restoreCmd.Execute()
+
+		// Assert
+		assert.Nil(t, loadErr)
+		assert.Nil(t, err)
+		assert.Nil(t, dryRunErr)
+		assert.Equal(t, []command.RestoreChange{{Name: "Deleted", Action: "create"}}, changes)
+	})
+}
+
+func TestSuccessfulRestoreChangesUpdate(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipelines := []map[string]interface{}{
+			{"application": "unparameterized-app", "name": "Same", "id": "8765", "updateTs": "1"},
+			{"application": "unparameterized-app", "name": "Changed", "id": "8765", "description": "changed"},
+		}
+
+		// Test
+		changes, err := command.RestoreChanges(deps, pipelines)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []command.RestoreChange{
+			{Name: "Same", Action: "update", Keys: []string{"application"}},
+			{Name: "Changed", Action: "update", Keys: []string{"application", "description"}},
+		}, changes)
+		assert.Equal(t, "Changed: update (application, description)", changes[1].String())
+	})
+}
+
+func TestFailedRestoreChangesBackendError(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipelines := []map[string]interface{}{{"application": "unavailable-app", "name": "Pipeline"}}
+
+		// Test
+		changes, err := command.RestoreChanges(deps, pipelines)
+
+		// Assert
+		assert.Nil(t, changes)
+		assert.EqualError(t, err, `failed to get the pipeline "Pipeline" of application "unavailable-app": 503 Service Unavailable`)
+	})
+}
+
+func TestSuccessfulRestoreResolvesReferences(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		childID := "7b6f7c6e-9d8f-4c1b-8a6e-2f0c7e1d2a3b"
+		writeBackup(deps, `{"application": "app", "pipelines": [{"name": "Parent", "id": "1", "file": "Parent.json"}, {"name": "Child", "id": "`+childID+`", "file": "Child.json"}]}`, map[string]string{
+			"Parent.json": `{"application": "app", "name": "Parent", "id": "1", "stages": [{"name": "Run", "type": "pipeline", "application": "app", "pipeline": "` + childID + `"}]}`,
+			"Child.json":  `{"application": "app", "name": "Child", "id": "` + childID + `"}`,
+		})
+		manifest, pipelines, _ := command.LoadBackup(deps, path.Join(testPath, "backup"))
+
+		restoreCmd := command.NewRestoreCommand(deps)
+		restoreCmd.SilenceErrors = true
+		restoreCmd.SilenceUsage = true
+		restoreCmd.SetArgs([]string{"backup"})
+
+		// Test
+		restorable := command.RestorablePipelines(manifest, pipelines)
+		err := restoreCmd.Execute()
+
+		// Assert
+		parentStage := restorable[0]["stages"].([]interface{})[0].(map[string]interface{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Child", parentStage["pipeline"])
+	})
+}
+
+func TestFailedRestoreNotABackup(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		restoreCmd := command.NewRestoreCommand(deps)
+		restoreCmd.SilenceErrors = true
+		restoreCmd.SilenceUsage = true
+		restoreCmd.SetArgs([]string{"missing"})
+		restoreCmd.SetOut(io.Discard)
+
+		// Test
+		err := restoreCmd.Execute()
+
+		// Assert
+		assert.ErrorContains(t, err, path.Join(testPath, "missing")+" is not a backup")
+	})
+}
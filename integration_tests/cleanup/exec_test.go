@@ -51,7 +51,7 @@ func TestSuccessfulExecWithFlag(t *testing.T) {
 func TestFailureExecWithConfigFileMissingParameter(t *testing.T) {
 	integration_tests.SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		execError := "required args key 'pipeline' missing"
+		execError := "invalid cleanup/exec configuration:\n\tcleanup/exec.json:1: missing required key \"pipeline\""
 		execConfig := `{"application": "First Application"}`
 
 		afero.WriteFile(deps.Project.FS, path.Join(testPath, "cleanup/exec.json"), []byte(execConfig), os.ModePerm)
@@ -71,7 +71,7 @@ func TestFailureExecWithConfigFileMissingParameter(t *testing.T) {
 func TestFailureExecWithFlagMissingParameter(t *testing.T) {
 	integration_tests.SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		execError := "required args key 'pipeline' missing"
+		execError := "invalid cleanup/exec configuration:\n\tflag: missing required key \"pipeline\""
 		execConfig := `{"application": "First Application"}`
 
 		// Test
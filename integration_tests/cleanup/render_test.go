@@ -129,7 +129,7 @@ func TestFailedRenderMissingParam(t *testing.T) {
 func TestFailedRenderMissingAllRenderFileWithRequiredParams(t *testing.T) {
 	integration_tests.SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := "<top-level-arg:params>:1:1 Unexpected end of file\n\n\n\n"
+		expectedErrorMessage := "<top-level-arg:params>:1:1 Unexpected end of file\n"
 
 		pipeline := `
 		function(params={})(
@@ -157,7 +157,7 @@ func TestFailedRenderMissingAllRenderFileWithRequiredParams(t *testing.T) {
 func TestFailedMalformedPipeline(t *testing.T) {
 	integration_tests.SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := fmt.Sprintf("%s/cleanup/cleanup.pipeline.jsonnet:6:3-4 Unexpected: \")\" while parsing field definition\n\n\t\t)\n\n", testPath)
+		expectedErrorMessage := "cleanup/cleanup.pipeline.jsonnet:6:3 Unexpected: \")\" while parsing field definition\n\n6 | \t\t)\n  | \t\t^\n"
 
 		pipeline := `
 		function(params={})(
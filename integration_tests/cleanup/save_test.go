@@ -1,7 +1,6 @@
 package cleanup_test
 
 import (
-	"fmt"
 	"os"
 	"path"
 	"testing"
@@ -46,7 +45,7 @@ func TestSuccessfulSaveWithConfigFile(t *testing.T) {
 func TestFailedSaveMissingParam(t *testing.T) {
 	integration_tests.SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := fmt.Sprintf("RUNTIME ERROR: Field does not exist: pipeline\n\t%s/cleanup/cleanup.pipeline.jsonnet:5:11-26\tobject <anonymous>\n\tField \"name\"\t\n\tDuring manifestation\t\n", testPath)
+		expectedErrorMessage := "cleanup/cleanup.pipeline.jsonnet:5:11 Field does not exist: pipeline\n\n5 | \t\t\t\tname: params.pipeline\n  | \t\t\t\t      ^^^^^^^^^^^^^^^\n"
 
 		renderConfig := `{"application":"Fourth Application"}`
 		afero.WriteFile(deps.Project.FS, path.Join(testPath, "cleanup/render.json"), []byte(renderConfig), os.ModePerm)
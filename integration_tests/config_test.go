@@ -0,0 +1,195 @@
+package integration_tests
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessfulConfigView(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+  config:
+    default: ~/.spin/config
+profiles:
+  default:
+    render: render.yml
+`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.yml"), []byte("application: app\n"), os.ModePerm)
+
+		var output bytes.Buffer
+		cmd := command.NewConfigViewCommand(deps)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetOut(&output)
+
+		// Test
+		err := cmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `KEY                      VALUE           SOURCE
+profile                  default         default
+executor-config          default         default
+renderer.type            jsonnet         shore.yml
+executor.type            spinnaker       shore.yml
+executor.config          ~/.spin/config  shore.yml
+profiles.default.render  render.yml      shore.yml
+render.application       app             render.yml
+`, output.String())
+	})
+}
+
+func TestSuccessfulConfigSetAndGet(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		var output bytes.Buffer
+
+		setCmd := command.NewConfigSetCommand(deps)
+		setCmd.SilenceErrors = true
+		setCmd.SilenceUsage = true
+		setCmd.SetArgs([]string{"executor.type", "spinnaker"})
+
+		getCmd := command.NewConfigGetCommand(deps)
+		getCmd.SilenceErrors = true
+		getCmd.SilenceUsage = true
+		getCmd.SetArgs([]string{"executor"})
+		getCmd.SetOut(&output)
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: tekton
+profiles:
+  default: {}
+`), os.ModePerm)
+
+		// Test
+		setErr := setCmd.Execute()
+		err := getCmd.Execute()
+
+		// Assert
+		assert.Nil(t, setErr)
+		assert.Nil(t, err)
+		assert.Equal(t, "type: spinnaker\n", output.String())
+	})
+}
+
+func TestFailedConfigValidate(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+profiles:
+  staging:
+    render: render.staging.yml
+`), os.ModePerm)
+
+		cmd := command.NewConfigValidateCommand(deps)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+
+		// Test
+		err := cmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "invalid shore configuration:\n\tshore.yml:6: profiles: missing required key \"default\"")
+	})
+}
+
+func TestConfigViewMasksSecrets(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		t.Setenv(config.SecretsKeyEnvVar, "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+		t.Setenv("SHORE_TEST_VIEW_TOKEN", "view-env-token")
+		t.Setenv("SHORE_TEST_VIEW_REGION", "us")
+		encrypted, _ := config.EncryptSecret("view-token-value")
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "token.enc"), []byte(encrypted), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+profiles:
+  default:
+    exec: exec.yml
+`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.yml"), []byte(`
+token: "Bearer ${encrypted-file:token.enc}"
+envToken: "${env:SHORE_TEST_VIEW_TOKEN}"
+region: "${env:SHORE_TEST_VIEW_REGION}"
+`), os.ModePerm)
+
+		var output bytes.Buffer
+		cmd := command.NewConfigViewCommand(deps)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetOut(&output)
+
+		// Test
+		err := cmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, output.String(), "Bearer ********")
+		assert.NotContains(t, output.String(), "view-token-value")
+		assert.NotContains(t, output.String(), "view-env-token")
+		assert.Regexp(t, `exec\.region +us +exec\.yml`, output.String())
+	})
+}
+
+func TestConfigViewWithProfileOverrides(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		t.Setenv("SHORE_PROFILE", "prod")
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+profiles:
+  default:
+    render: render.yml
+  prod:
+    render: render.prod.yml
+`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.yml"), []byte("application: app\nreplicas: 1\n"), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.prod.yml"), []byte("replicas: 3\n"), os.ModePerm)
+
+		var output bytes.Buffer
+		cmd := command.NewConfigViewCommand(deps)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetOut(&output)
+
+		// Test
+		err := cmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `KEY                   VALUE            SOURCE
+profile               prod             $SHORE_PROFILE
+executor-config       default          default
+renderer.type         jsonnet          shore.yml
+executor.type         spinnaker        shore.yml
+profiles.prod.render  render.prod.yml  shore.yml
+render.application    app              render.yml
+render.replicas       3                render.prod.yml
+`, output.String())
+	})
+}
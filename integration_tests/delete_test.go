@@ -1,7 +1,6 @@
 package integration_tests
 
 import (
-	"fmt"
 	"os"
 	"path"
 	"testing"
@@ -43,7 +42,7 @@ func TestSuccessfulDeleteWithConfigFile(t *testing.T) {
 func TestFailedDeleteMissingParam(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := fmt.Sprintf("RUNTIME ERROR: Field does not exist: pipeline\n\t%v/main.pipeline.jsonnet:5:11-26\tobject <anonymous>\n\tField \"name\"\t\n\tDuring manifestation\t\n", testPath)
+		expectedErrorMessage := "main.pipeline.jsonnet:5:11 Field does not exist: pipeline\n\n5 | \t\t\t\tname: params.pipeline\n  | \t\t\t\t      ^^^^^^^^^^^^^^^\n"
 
 		renderConfig := `{"application":"Fourth Application"}`
 		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
@@ -96,3 +95,47 @@ func TestSuccessDeleteCommandLineDryRun(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestSuccessfulDeleteCrossApplicationNestedPipeline(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		renderConfig := `{"application": "platform", "pipeline": "Orchestrator"}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
+
+		pipeline := `
+		function(params={})(
+			{
+				application: params.application,
+				name: params.pipeline,
+				stages: [
+					{
+						application: 'team-a',
+						name: 'Deploy team A',
+						type: 'pipeline',
+						pipeline: {application: 'team-a', name: 'Deploy', stages: []},
+					},
+				],
+			}
+		)
+		`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		deleteCmd := command.NewDeleteCommand(deps)
+		deleteCmd.SilenceErrors = true
+		deleteCmd.SilenceUsage = true
+		deleteCmd.Flags().Set("dry-run", "true")
+		dryRunErr := deleteCmd.Execute()
+
+		deleteCmd = command.NewDeleteCommand(deps)
+		deleteCmd.SilenceErrors = true
+		deleteCmd.SilenceUsage = true
+		err := deleteCmd.Execute()
+
+		// Assert
+		assert.Nil(t, dryRunErr)
+		assert.Nil(t, err)
+	})
+}
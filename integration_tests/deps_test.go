@@ -0,0 +1,37 @@
+package integration_tests
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessfulDepsList(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "jsonnetfile.json"), []byte(`{
+	"version": 1,
+	"dependencies": [
+		{"source": {"local": {"directory": "libs/mylib"}}, "version": ""}
+	]
+}`), os.ModePerm)
+
+		var output bytes.Buffer
+		listCmd := command.NewDepsListCommand(deps)
+		listCmd.SilenceErrors = true
+		listCmd.SilenceUsage = true
+		listCmd.SetOut(&output)
+
+		// Test
+		err := listCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "NAME   VERSION  LOCKED\nmylib           (not installed)\n", output.String())
+	})
+}
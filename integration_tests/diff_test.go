@@ -0,0 +1,394 @@
+package integration_tests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/Autodesk/shore/pkg/renderer/jsonnet"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessfulDiffOutputs(t *testing.T) {
+	for _, output := range []string{"console", "unified", "json-patch", "summary"} {
+		t.Run(output, func(t *testing.T) {
+			SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+				// Given
+				setupHistoryProject(deps)
+
+				diffCmd := command.NewDiffCommand(deps)
+				diffCmd.SilenceErrors = true
+				diffCmd.SilenceUsage = true
+				diffCmd.Flags().Set("output", output)
+				diffCmd.Flags().Set("ignore", "description")
+				diffCmd.Flags().Set("ignore", "stages[*].application")
+
+				// Test
+				err := diffCmd.Execute()
+
+				// Assert
+				assert.Nil(t, err)
+			})
+		})
+	}
+}
+
+func TestSuccessfulDiffJSONPatchSingleDocument(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(`function(params={}) [
+	{"application": "app", "name": "first", "description": "new"},
+	{"application": "app", "name": "second", "description": "same"},
+]`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "old.json"), []byte(`[
+	{"application": "app", "name": "first", "description": "old"},
+	{"application": "app", "name": "second", "description": "same"}
+]`), os.ModePerm)
+
+		var output bytes.Buffer
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.SetOut(&output)
+		diffCmd.Flags().Set("against-file", "old.json")
+		diffCmd.Flags().Set("output", "json-patch")
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"app/first": [{"op": "replace", "path": "/description", "value": "new"}],
+			"app/second": []
+		}`, output.String())
+	})
+}
+
+func TestFailedDiffUnsupportedOutput(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("output", "xml")
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `unsupported diff output "xml", expected one of: console, unified, json-patch, summary`)
+	})
+}
+
+func TestFailedDiffInvalidIgnorePath(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte("diff:\n  ignore:\n    - stages[\n"), os.ModePerm)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `invalid path "stages[": unbalanced brackets`)
+	})
+}
+
+func TestSuccessfulDiffAgainstProfile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.prod.json"), []byte(`{"pipeline": "ProdParent"}`), os.ModePerm)
+
+		// Test
+		source, err := command.DiffAgainstProfile(deps, "prod", "", command.RenderFlags{})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "profile prod", source.Label)
+		assert.Len(t, source.Pipelines, 1)
+		assert.Equal(t, "ProdParent", source.Pipelines[0]["name"])
+	})
+}
+
+func TestSuccessfulDiffAgainstProfileCommand(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.prod.json"), []byte(`{"pipeline": "ProdParent"}`), os.ModePerm)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("against-profile", "prod")
+		diffCmd.Flags().Set("output", "summary")
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestSuccessfulDiffAgainstFile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "old.json"), []byte(`[{"application": "history-app", "name": "Parent", "id": "1234"}]`), os.ModePerm)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("against-file", path.Join(testPath, "old.json"))
+		diffCmd.Flags().Set("output", "unified")
+
+		// Test
+		source, sourceErr := command.DiffAgainstFile(deps, path.Join(testPath, "old.json"))
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.Nil(t, sourceErr)
+		assert.Equal(t, "file /test/old.json", source.Label)
+		assert.Equal(t, []map[string]interface{}{{"application": "history-app", "name": "Parent", "id": "1234"}}, source.Pipelines)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSuccessfulDiffAgainstFileRelativeToProject(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "old.json"), []byte(`{"application": "history-app", "name": "Parent"}`), os.ModePerm)
+
+		// Test
+		source, err := command.DiffAgainstFile(deps, "old.json")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "file old.json", source.Label)
+		assert.Equal(t, []map[string]interface{}{{"application": "history-app", "name": "Parent"}}, source.Pipelines)
+	})
+}
+
+func TestFailedDiffAgainstFileNotExists(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("against-file", path.Join(testPath, "old.json"))
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestFailedDiffAgainstWithRevision(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.SetArgs([]string{"--against-profile", "prod", "--revision", "1"})
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "if any flags in the group [against-profile against-file against-git-ref revision] are set none of the others can be; [against-profile revision] were all set")
+	})
+}
+
+func TestSuccessfulDiffAgainstGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Given
+	repositoryPath := t.TempDir()
+	projectPath := path.Join(repositoryPath, "pipelines")
+	runGit := func(args ...string) string {
+		output, err := exec.Command("git", append([]string{"-C", repositoryPath, "-c", "user.name=shore", "-c", "user.email=shore@example.com"}, args...)...).CombinedOutput()
+		assert.Nil(t, err, string(output))
+		return string(output)
+	}
+
+	fs := afero.NewOsFs()
+	logger, _ := test.NewNullLogger()
+	deps := &command.Dependencies{
+		Project:  &project.Project{FS: fs, Log: logger, Path: projectPath},
+		Renderer: jsonnet.NewRenderer(fs, logger),
+		Logger:   logger,
+	}
+
+	fs.MkdirAll(projectPath, os.ModePerm)
+	afero.WriteFile(fs, path.Join(projectPath, "main.pipeline.jsonnet"), []byte(historyPipeline), os.ModePerm)
+	afero.WriteFile(fs, path.Join(projectPath, "render.json"), []byte(`{"application": "history-app", "pipeline": "Parent"}`), os.ModePerm)
+	runGit("init", "--quiet")
+	runGit("add", "-A")
+	runGit("commit", "--quiet", "-m", "Initial commit")
+	afero.WriteFile(fs, path.Join(projectPath, "render.json"), []byte(`{"application": "history-app", "pipeline": "Renamed"}`), os.ModePerm)
+
+	// Test
+	source, err := command.DiffAgainstGitRef(deps, "HEAD", "default", "", command.RenderFlags{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "git ref HEAD", source.Label)
+	assert.Len(t, source.Pipelines, 1)
+	assert.Equal(t, "Parent", source.Pipelines[0]["name"])
+	assert.Len(t, strings.Split(strings.TrimSpace(runGit("worktree", "list")), "\n"), 1)
+}
+
+func TestFailedDiffAgainstGitRefNotExists(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Given
+	repositoryPath := t.TempDir()
+	output, err := exec.Command("git", "-C", repositoryPath, "init", "--quiet").CombinedOutput()
+	assert.Nil(t, err, string(output))
+
+	fs := afero.NewOsFs()
+	logger, _ := test.NewNullLogger()
+	deps := &command.Dependencies{
+		Project:  &project.Project{FS: fs, Log: logger, Path: repositoryPath},
+		Renderer: jsonnet.NewRenderer(fs, logger),
+		Logger:   logger,
+	}
+
+	// Test
+	_, err = command.DiffAgainstGitRef(deps, "missing-ref", "default", "", command.RenderFlags{})
+
+	// Assert
+	assert.ErrorContains(t, err, "git worktree add --detach")
+}
+
+const diffPipeline = `
+function(params={})(
+	{
+		application: params.application,
+		name: "Diff Pipeline",
+		stages: [],
+	}
+)
+`
+
+func TestSuccessfulDiffPipelineNotExists(t *testing.T) {
+	for _, application := range []string{"not-exists", "missing-app"} {
+		t.Run(application, func(t *testing.T) {
+			SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+				// Given
+				afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(diffPipeline), os.ModePerm)
+
+				// Test
+				err := command.Diff(deps, []byte(`{"application": "`+application+`"}`), renderer.MainFileName, command.DiffOptions{})
+
+				// Assert
+				assert.Nil(t, err)
+			})
+		})
+	}
+}
+
+func TestSuccessfulDiffRenderArgsWithoutPipeline(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(diffPipeline), os.ModePerm)
+
+		// Test
+		err := command.Diff(deps, []byte(`{"application": "app"}`), renderer.MainFileName, command.DiffOptions{})
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedDiffBackendError(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(diffPipeline), os.ModePerm)
+
+		// Test
+		err := command.Diff(deps, []byte(`{"application": "unavailable-app"}`), renderer.MainFileName, command.DiffOptions{})
+
+		// Assert
+		var pipelineErr *command.DiffPipelineErr
+		assert.True(t, errors.As(err, &pipelineErr))
+		assert.Equal(t, "unavailable-app", pipelineErr.Application)
+		assert.Equal(t, "Diff Pipeline", pipelineErr.Pipeline)
+		assert.EqualError(t, err, `failed to get the pipeline "Diff Pipeline" of application "unavailable-app": 503 Service Unavailable`)
+	})
+}
+
+func TestFailedDiffRenderError(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(`function(params={})( { application: `), os.ModePerm)
+
+		// Test
+		err := command.Diff(deps, []byte(`{"application": "app"}`), renderer.MainFileName, command.DiffOptions{})
+
+		// Assert
+		var renderErr *command.DiffRenderErr
+		assert.True(t, errors.As(err, &renderErr))
+	})
+}
+
+func TestFailedDiffPipelineWithoutApplication(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(`function(params={})({name: "Diff Pipeline", stages: []})`), os.ModePerm)
+
+		// Test
+		err := command.Diff(deps, []byte(`{}`), renderer.MainFileName, command.DiffOptions{})
+
+		// Assert
+		var invalidErr *command.InvalidPipelineErr
+		assert.True(t, errors.As(err, &invalidErr))
+		assert.EqualError(t, err, `the rendered pipeline "Diff Pipeline" has no "application" (string), it is required to compare the pipeline`)
+	})
+}
+
+func TestFailedDiffRevisionOutOfRange(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("revision", "5")
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `pipeline "Parent" of application "history-app" has no revision 5, its oldest revision is 2`)
+	})
+}
@@ -0,0 +1,164 @@
+package integration_tests
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/diff"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const driftPipeline = `
+function(params={})(
+	{
+		application: params.application,
+		name: "Parent",
+		description: "managed",
+		stages: [
+			{
+				name: "Run Child",
+				type: "pipeline",
+				application: params.application,
+				pipeline: {
+					application: params.application,
+					name: "Child",
+					description: "managed",
+				},
+			},
+		],
+	}
+)
+`
+
+func TestSuccessfulDetectDrift(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipelines := []map[string]interface{}{
+			{
+				"application": "drift-app",
+				"name":        "Parent",
+				"description": "managed",
+				"stages": []interface{}{
+					map[string]interface{}{"name": "Run Child", "type": "pipeline", "application": "drift-app", "pipeline": map[string]interface{}{
+						"application": "drift-app",
+						"name":        "Child",
+						"description": "managed",
+					}},
+				},
+			},
+			{"application": "drift-app", "name": "Missing"},
+		}
+
+		// Test
+		report, err := command.DetectDrift(deps, pipelines, diff.DefaultOptions())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, &command.DriftReport{
+			Drifted: 2,
+			Pipelines: []command.PipelineDrift{
+				{Application: "drift-app", Name: "Child", Status: command.DriftStatusInSync, LastModifiedBy: "alice", UpdateTs: "2023-11-14T22:13:20Z"},
+				{Application: "drift-app", Name: "Parent", Status: command.DriftStatusDrifted, Paths: []string{"description"}, LastModifiedBy: "bob", UpdateTs: "2023-11-14T22:15:00Z"},
+				{Application: "drift-app", Name: "Missing", Status: command.DriftStatusMissing},
+			},
+		}, report)
+		assert.Equal(t, "managed", pipelines[0]["stages"].([]interface{})[0].(map[string]interface{})["pipeline"].(map[string]interface{})["description"])
+	})
+}
+
+func TestFailedDriftCommandDrifted(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "drift-app"}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(driftPipeline), os.ModePerm)
+
+		driftCmd := command.NewDriftCommand(deps)
+		driftCmd.SilenceErrors = true
+		driftCmd.SilenceUsage = true
+		driftCmd.Flags().Set("output", "json")
+
+		// Test
+		err := driftCmd.Execute()
+
+		// Assert
+		var exitCoder command.ExitCoder
+
+		assert.EqualError(t, err, "1 pipelines drifted from the project")
+		assert.True(t, errors.As(err, &exitCoder))
+		assert.Equal(t, command.DriftExitCode, exitCoder.ExitCode())
+	})
+}
+
+func TestSuccessfulDriftCommandIgnoredPaths(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "drift-app"}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(driftPipeline), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte("diff:\n  ignore:\n    - description\n"), os.ModePerm)
+
+		driftCmd := command.NewDriftCommand(deps)
+		driftCmd.SilenceErrors = true
+		driftCmd.SilenceUsage = true
+		driftCmd.Flags().Set("output", "json")
+
+		// Test
+		err := driftCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedDriftCommandUnsupportedOutput(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		driftCmd := command.NewDriftCommand(deps)
+		driftCmd.SilenceErrors = true
+		driftCmd.SilenceUsage = true
+		driftCmd.Flags().Set("output", "yaml")
+
+		// Test
+		err := driftCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `unsupported output "yaml", expected one of: table, json`)
+	})
+}
+
+func TestFailedDetectDriftBackendError(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipelines := []map[string]interface{}{{"application": "unavailable-app", "name": "Parent"}}
+
+		// Test
+		report, err := command.DetectDrift(deps, pipelines, diff.DefaultOptions())
+
+		// Assert
+		var exitCoder command.ExitCoder
+
+		assert.Nil(t, report)
+		assert.EqualError(t, err, `failed to get the pipeline "Parent" of application "unavailable-app": 503 Service Unavailable`)
+		assert.False(t, errors.As(err, &exitCoder))
+	})
+}
+
+func TestSuccessfulDetectDriftPipelineNotFound(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipelines := []map[string]interface{}{{"application": "missing-app", "name": "Parent"}}
+
+		// Test
+		report, err := command.DetectDrift(deps, pipelines, diff.DefaultOptions())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, &command.DriftReport{
+			Drifted:   1,
+			Pipelines: []command.PipelineDrift{{Application: "missing-app", Name: "Parent", Status: command.DriftStatusMissing}},
+		}, report)
+	})
+}
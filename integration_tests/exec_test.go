@@ -84,7 +84,7 @@ func TestSuccessfulExecWithStringifyFlagFalse(t *testing.T) {
 func TestFailureExecWithConfigFileMissingParameter(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		execError := "required args key 'pipeline' missing"
+		execError := "invalid exec configuration:\n\texec.json:1: missing required key \"pipeline\""
 		execConfig := `{"application": "First Application"}`
 
 		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.json"), []byte(execConfig), os.ModePerm)
@@ -104,7 +104,7 @@ func TestFailureExecWithConfigFileMissingParameter(t *testing.T) {
 func TestFailureExecWithFlagMissingParameter(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		execError := "required args key 'pipeline' missing"
+		execError := "invalid exec configuration:\n\tflag: missing required key \"pipeline\""
 		execConfig := `{"application": "First Application"}`
 
 		// Test
@@ -154,3 +154,83 @@ func TestFailureExecWithFlagBadPayload(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSuccessfulExecWithTrigger(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		execConfig := `application: triggered-app
+pipeline: First Pipeline
+trigger:
+  type: webhook
+  user: ci-bot
+  source: my-webhook
+  payload:
+    ref: main
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.yml"), []byte(execConfig), os.ModePerm)
+
+		// Test
+		execCmd := command.NewExecCommand(deps, "exec")
+		execCmd.SilenceErrors = true
+		execCmd.SilenceUsage = true
+		err := execCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedExecWithUnmatchedTrigger(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		execConfig := `{"application": "First Application", "pipeline": "First Pipeline", "trigger": {"type": "webhook", "source": "my-webhook"}}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.json"), []byte(execConfig), os.ModePerm)
+
+		// Test
+		execCmd := command.NewExecCommand(deps, "exec")
+		execCmd.SilenceErrors = true
+		execCmd.SilenceUsage = true
+		err := execCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `pipeline "First Pipeline" of application "First Application": no webhook trigger (source: my-webhook) is configured`)
+	})
+}
+
+func TestFailedExecWithInvalidTriggerType(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		execConfig := `{"application": "First Application", "pipeline": "First Pipeline", "trigger": {"type": "jenkinz"}}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.json"), []byte(execConfig), os.ModePerm)
+
+		// Test
+		execCmd := command.NewExecCommand(deps, "exec")
+		execCmd.SilenceErrors = true
+		execCmd.SilenceUsage = true
+		err := execCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "invalid exec configuration:\n\texec.json:1: trigger.type: unsupported value jenkinz, expected one of: manual, jenkins, concourse, webhook, docker, git, pipeline")
+	})
+}
+
+func TestFailedExecWithInvalidParameters(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		execConfig := `{"application": "parameters-app", "pipeline": "First Pipeline", "parameters": {"region": "ap-south-1", "replica": 3}}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "exec.json"), []byte(execConfig), os.ModePerm)
+
+		// Test
+		execCmd := command.NewExecCommand(deps, "exec")
+		execCmd.SilenceErrors = true
+		execCmd.SilenceUsage = true
+		err := execCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "invalid parameters for pipeline \"First Pipeline\":\n\tparameter \"region\" value \"ap-south-1\" isn't one of the options: us-east-1, eu-west-1\n\tunknown parameter \"replica\"")
+	})
+}
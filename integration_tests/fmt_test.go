@@ -0,0 +1,91 @@
+package integration_tests
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailedFmtCheckUnformattedFiles(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipeline := "function(params={})(\n{\"a\":   \"b\"}\n)\n"
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		fmtCmd := command.NewFmtCommand(deps)
+		fmtCmd.SilenceErrors = true
+		fmtCmd.SilenceUsage = true
+		err := fmtCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "the following files are not formatted (run `shore fmt --write`):\n\tmain.pipeline.jsonnet")
+	})
+}
+
+func TestSuccessfulFmtWrite(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipeline := "function(params={})(\n{\"a\":   \"b\"}\n)\n"
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		fmtCmd := command.NewFmtCommand(deps)
+		fmtCmd.SilenceErrors = true
+		fmtCmd.SilenceUsage = true
+		fmtCmd.Flags().Set("write", "true")
+		err := fmtCmd.Execute()
+
+		checkCmd := command.NewFmtCommand(deps)
+		checkCmd.SilenceErrors = true
+		checkCmd.SilenceUsage = true
+		checkErr := checkCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, checkErr)
+	})
+}
+
+func TestFailedLintUnusedVariable(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipeline := "local unused = 'a';\n\nfunction(params={}) { a: params }\n"
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		var output bytes.Buffer
+
+		// Test
+		lintCmd := command.NewLintCommand(deps)
+		lintCmd.SilenceErrors = true
+		lintCmd.SilenceUsage = true
+		lintCmd.SetOut(&output)
+		err := lintCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "lint issues were found")
+		assert.Contains(t, output.String(), "unused")
+	})
+}
+
+func TestSuccessfulLint(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipeline := "function(params={}) { a: params }\n"
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		lintCmd := command.NewLintCommand(deps)
+		lintCmd.SilenceErrors = true
+		lintCmd.SilenceUsage = true
+		err := lintCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
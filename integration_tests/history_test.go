@@ -0,0 +1,189 @@
+package integration_tests
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/renderer"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const historyPipeline = `
+function(params={})(
+	{
+		application: params.application,
+		name: params.pipeline,
+		stages: [
+			{
+				name: "Run Child",
+				type: "pipeline",
+				application: params.application,
+				pipeline: {
+					application: params.application,
+					name: "Child",
+				},
+			},
+		],
+	}
+)
+`
+
+func setupHistoryProject(deps *command.Dependencies) {
+	afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "history-app", "pipeline": "Parent"}`), os.ModePerm)
+	afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(historyPipeline), os.ModePerm)
+}
+
+func TestSuccessfulGetPipelineRevisions(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Test
+		revisions, err := command.GetPipelineRevisions(deps, "history-app", "Parent", 0)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, revisions, 3)
+		assert.Equal(t, 1, revisions[1].Number)
+		assert.Equal(t, "bob", revisions[1].User)
+		assert.Equal(t, time.UnixMilli(1700000200000).UTC(), revisions[1].Timestamp)
+	})
+}
+
+func TestFailedGetPipelineRevisionOutOfRange(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Test
+		_, err := command.GetPipelineRevision(deps, "history-app", "Parent", 3)
+
+		// Assert
+		assert.EqualError(t, err, `pipeline "Parent" of application "history-app" has no revision 3, its oldest revision is 2`)
+	})
+}
+
+func TestFailedGetPipelineRevisionPipelineNotExists(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Test
+		_, err := command.GetPipelineRevision(deps, "not-exists", "Parent", 1)
+
+		// Assert
+		assert.EqualError(t, err, `pipeline "Parent" doesn't exist in application "not-exists"`)
+	})
+}
+
+func TestSuccessfulHistoryCommand(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		var output bytes.Buffer
+		historyCmd := command.NewHistoryCommand(deps)
+		historyCmd.SilenceErrors = true
+		historyCmd.SilenceUsage = true
+		historyCmd.SetOut(&output)
+
+		// Test
+		err := historyCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, output.String(), "Application: history-app\nPipeline: Parent\n")
+		assert.Regexp(t, `REVISION +TIMESTAMP +USER\n0 +.+ +carol\n1 +.+ +bob\n`, output.String())
+	})
+}
+
+func TestSuccessfulRollbackPipelines(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		pipelineJSON, _ := command.Render(deps, []byte(`{"application": "history-app", "pipeline": "Parent"}`), renderer.MainFileName)
+		var pipeline map[string]interface{}
+		jsoniter.UnmarshalFromString(pipelineJSON, &pipeline)
+
+		// Test
+		pipelines, err := command.RollbackPipelines(deps, pipeline, 1)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, pipelines, 2)
+		assert.Equal(t, "Child", pipelines[0]["name"])
+		assert.Equal(t, "revision 1", pipelines[0]["description"])
+		assert.Equal(t, "Parent", pipelines[1]["name"])
+		assert.Equal(t, "revision 1", pipelines[1]["description"])
+	})
+}
+
+func TestSuccessfulRollbackPipelinesOldestRevision(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+		pipelineJSON, _ := command.Render(deps, []byte(`{"application": "history-app", "pipeline": "Parent"}`), renderer.MainFileName)
+		var pipeline map[string]interface{}
+		jsoniter.UnmarshalFromString(pipelineJSON, &pipeline)
+
+		// Test
+		pipelines, err := command.RollbackPipelines(deps, pipeline, 2)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, pipelines, 2)
+		assert.Equal(t, "revision 2", pipelines[0]["description"])
+		assert.Equal(t, "revision 2", pipelines[1]["description"])
+	})
+}
+
+func TestSuccessfulRollbackCommand(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		rollbackCmd := command.NewRollbackCommand(deps)
+		rollbackCmd.SilenceErrors = true
+		rollbackCmd.SilenceUsage = true
+		rollbackCmd.Flags().Set("revision", "1")
+
+		// Test
+		err := rollbackCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedRollbackCommandCurrentRevision(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		rollbackCmd := command.NewRollbackCommand(deps)
+		rollbackCmd.SilenceErrors = true
+		rollbackCmd.SilenceUsage = true
+		rollbackCmd.Flags().Set("revision", "0")
+
+		// Test
+		err := rollbackCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "the revision must be 1 or more, revision 0 is the current configuration")
+	})
+}
+
+func TestSuccessfulDiffRevision(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		setupHistoryProject(deps)
+
+		diffCmd := command.NewDiffCommand(deps)
+		diffCmd.SilenceErrors = true
+		diffCmd.SilenceUsage = true
+		diffCmd.Flags().Set("revision", "1")
+
+		// Test
+		err := diffCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
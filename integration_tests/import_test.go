@@ -0,0 +1,142 @@
+package integration_tests
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// vendorSpinLibJsonnet - Installs the `spin-lib-jsonnet` libraries of the repository, as `shore deps install` would.
+func vendorSpinLibJsonnet(deps *command.Dependencies) {
+	libPath := filepath.Join("..", "jsonnet", "libs", "spin-lib-jsonnet")
+	vendorPath := path.Join(testPath, "vendor", "github.com", "Autodesk", "shore", "jsonnet", "libs", "spin-lib-jsonnet")
+	files, _ := os.ReadDir(libPath)
+
+	for _, file := range files {
+		content, _ := os.ReadFile(filepath.Join(libPath, file.Name()))
+		afero.WriteFile(deps.Project.FS, path.Join(vendorPath, file.Name()), content, os.ModePerm)
+	}
+}
+
+func TestSuccessfulImport(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		importCmd := command.NewImportCommand(deps)
+		importCmd.SilenceErrors = true
+		importCmd.SilenceUsage = true
+		importCmd.Flags().Set("application", "triggered-app")
+		importCmd.Flags().Set("pipeline", "My Pipeline")
+
+		renderCmd := command.NewRenderCommand(deps)
+		renderCmd.SilenceErrors = true
+		renderCmd.SilenceUsage = true
+
+		// Test
+		err := importCmd.Execute()
+		vendorSpinLibJsonnet(deps)
+		renderErr := renderCmd.Execute()
+
+		// Assert
+		mainFile, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"))
+		renderConfig, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "render.yml"))
+		jsonnetFile, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "jsonnetfile.json"))
+		shoreConfig, shoreConfigErr := afero.ReadFile(deps.Project.FS, path.Join(testPath, "shore.yml"))
+
+		assert.Nil(t, err)
+		assert.Nil(t, renderErr)
+		assert.Nil(t, shoreConfigErr)
+		assert.Contains(t, string(mainFile), "trigger.JenkinsTrigger {")
+		assert.Contains(t, string(mainFile), "trigger.WebhookTrigger {")
+		assert.Contains(t, string(mainFile), "pipeline: 'Triggered Pipeline',")
+		assert.NotContains(t, string(mainFile), "5678")
+		assert.Equal(t, "application: triggered-app\npipeline: My Pipeline\n", string(renderConfig))
+		assert.Contains(t, string(jsonnetFile), "jsonnet/libs/spin-lib-jsonnet")
+		assert.Contains(t, string(shoreConfig), "render: render.yml")
+		assert.Contains(t, string(shoreConfig), "name: test\n")
+	})
+}
+
+func TestSuccessfulImportWithName(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		importCmd := command.NewImportCommand(deps)
+		importCmd.SilenceErrors = true
+		importCmd.SilenceUsage = true
+		importCmd.Flags().Set("application", "triggered-app")
+		importCmd.Flags().Set("pipeline", "My Pipeline")
+		importCmd.Flags().Set("name", "my-pipelines")
+
+		// Test
+		err := importCmd.Execute()
+
+		// Assert
+		shoreConfig, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "shore.yml"))
+
+		assert.Nil(t, err)
+		assert.Contains(t, string(shoreConfig), "name: my-pipelines\n")
+	})
+}
+
+func TestSuccessfulImportParameters(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		importCmd := command.NewImportCommand(deps)
+		importCmd.SilenceErrors = true
+		importCmd.SilenceUsage = true
+		importCmd.Flags().Set("application", "parameters-app")
+		importCmd.Flags().Set("pipeline", "My Pipeline")
+
+		// Test
+		err := importCmd.Execute()
+
+		// Assert
+		execConfig, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "exec.yml"))
+		e2eConfig, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "E2E.yml"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "application: parameters-app\npipeline: My Pipeline\nparameters:\n  region: \"\"\n  replicas: \"2\"\n  dryRun: \"\"\n", string(execConfig))
+		assert.Contains(t, string(e2eConfig), "Test Success:")
+	})
+}
+
+func TestFailedImportMissingPipeline(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		importCmd := command.NewImportCommand(deps)
+		importCmd.SilenceErrors = true
+		importCmd.SilenceUsage = true
+		importCmd.Flags().Set("application", "not-exists")
+		importCmd.Flags().Set("pipeline", "My Pipeline")
+
+		// Test
+		err := importCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `pipeline "My Pipeline" doesn't exist in application "not-exists"`)
+	})
+}
+
+func TestFailedImportExistingProject(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte("{}"), os.ModePerm)
+
+		importCmd := command.NewImportCommand(deps)
+		importCmd.SilenceErrors = true
+		importCmd.SilenceUsage = true
+		importCmd.Flags().Set("application", "triggered-app")
+		importCmd.Flags().Set("pipeline", "My Pipeline")
+
+		// Test
+		err := importCmd.Execute()
+
+		// Assert
+		assert.ErrorContains(t, err, "refusing to overwrite existing files")
+		assert.ErrorContains(t, err, "main.pipeline.jsonnet")
+	})
+}
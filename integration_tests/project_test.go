@@ -0,0 +1,105 @@
+package integration_tests
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessfulNonInteractiveProjectInit(t *testing.T) {
+	for _, template := range project.TemplateNames() {
+		t.Run(template, func(t *testing.T) {
+			SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+				// Given
+				initCmd := command.NewProjectInitCommand(deps)
+				initCmd.SilenceErrors = true
+				initCmd.SilenceUsage = true
+				initCmd.Flags().Set("name", "my-project")
+				initCmd.Flags().Set("template", template)
+				initCmd.Flags().Set("yes", "true")
+
+				renderCmd := command.NewRenderCommand(deps)
+				renderCmd.SilenceErrors = true
+				renderCmd.SilenceUsage = true
+
+				// Test
+				err := initCmd.Execute()
+				renderErr := renderCmd.Execute()
+
+				// Assert
+				readme, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "README.md"))
+
+				assert.Nil(t, err)
+				assert.Nil(t, renderErr)
+				assert.Contains(t, string(readme), "A Jsonnet project for Spinnaker")
+			})
+		})
+	}
+}
+
+func TestFailedProjectInitUnsupportedBackend(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		initCmd := command.NewProjectInitCommand(deps)
+		initCmd.SilenceErrors = true
+		initCmd.SilenceUsage = true
+		initCmd.Flags().Set("backend", "tekton")
+		initCmd.Flags().Set("yes", "true")
+
+		// Test
+		err := initCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `unsupported backend "tekton", expected one of: Spinnaker`)
+	})
+}
+
+func TestFailedProjectInitExistingProject(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		firstInitCmd := command.NewProjectInitCommand(deps)
+		firstInitCmd.SilenceErrors = true
+		firstInitCmd.SilenceUsage = true
+		firstInitCmd.Flags().Set("yes", "true")
+
+		initCmd := command.NewProjectInitCommand(deps)
+		initCmd.SilenceErrors = true
+		initCmd.SilenceUsage = true
+		initCmd.Flags().Set("yes", "true")
+
+		// Test
+		firstErr := firstInitCmd.Execute()
+		err := initCmd.Execute()
+
+		// Assert
+		assert.Nil(t, firstErr)
+		assert.ErrorContains(t, err, "refusing to overwrite existing files (use --force to overwrite them): .gitignore, E2E.yml")
+	})
+}
+
+func TestSuccessfulProjectInitWithProfiles(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		initCmd := command.NewProjectInitCommand(deps)
+		initCmd.SilenceErrors = true
+		initCmd.SilenceUsage = true
+		initCmd.Flags().Set("yes", "true")
+		initCmd.Flags().Set("add-profile", "staging")
+
+		// Test
+		err := initCmd.Execute()
+
+		// Assert
+		shoreConfig, _ := afero.ReadFile(deps.Project.FS, path.Join(testPath, "shore.yml"))
+		stagingRenderExists, _ := afero.Exists(deps.Project.FS, path.Join(testPath, "render.staging.yml"))
+
+		assert.Nil(t, err)
+		assert.Contains(t, string(shoreConfig), "profiles:\n  default:\n    render: render.yml\n")
+		assert.Contains(t, string(shoreConfig), "  staging:\n    render: render.staging.yml\n")
+		assert.True(t, stagingRenderExists)
+	})
+}
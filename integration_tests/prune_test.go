@@ -0,0 +1,143 @@
+package integration_tests
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/Autodesk/shore/pkg/command"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const prunePipeline = `
+function(params={})(
+	{
+		application: params.application,
+		name: "Current Pipeline"
+	}
+)
+`
+
+const pruneShoreConfig = `
+name: test
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+profiles:
+  default:
+    render: render.json
+`
+
+func TestSuccessfulFindOrphanedPipelines(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(pruneShoreConfig), os.ModePerm)
+		owner, _ := command.ProjectOwnership(deps, renderer.MainFileName, "default")
+
+		// Test
+		orphans, err := command.FindOrphanedPipelines(deps, `{"application": "prune-app", "name": "Current Pipeline"}`, owner)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, &backend.Ownership{Project: "test", Source: "main", Profile: "default"}, owner)
+		assert.Equal(t, []backend.ApplicationPipelines{{Application: "prune-app", Names: []string{"Renamed Pipeline"}}}, orphans)
+	})
+}
+
+func TestSuccessfulFindOrphanedPipelinesOtherProfile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(pruneShoreConfig), os.ModePerm)
+		owner, _ := command.ProjectOwnership(deps, renderer.MainFileName, "prod")
+
+		// Test
+		orphans, err := command.FindOrphanedPipelines(deps, `{"application": "prune-app", "name": "Current Pipeline"}`, owner)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []backend.ApplicationPipelines{{Application: "prune-app", Names: []string{"Other Profile Pipeline"}}}, orphans)
+	})
+}
+
+func TestSuccessfulProjectOwnershipWithoutName(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Test
+		owner, err := command.ProjectOwnership(deps, renderer.MainFileName, "default")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, owner)
+	})
+}
+
+func TestSuccessfulPruneCommand(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(pruneShoreConfig), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "prune-app"}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(prunePipeline), os.ModePerm)
+
+		// Test
+		pruneCmd := command.NewPruneCommand(deps)
+		pruneCmd.SilenceErrors = true
+		pruneCmd.SilenceUsage = true
+		listErr := pruneCmd.Execute()
+
+		pruneCmd = command.NewPruneCommand(deps)
+		pruneCmd.SilenceErrors = true
+		pruneCmd.SilenceUsage = true
+		pruneCmd.Flags().Set("delete", "true")
+		deleteErr := pruneCmd.Execute()
+
+		// Assert
+		assert.Nil(t, listErr)
+		assert.Nil(t, deleteErr)
+	})
+}
+
+func TestFailedPruneCommandWithoutProjectName(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "prune-app"}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(prunePipeline), os.ModePerm)
+
+		pruneCmd := command.NewPruneCommand(deps)
+		pruneCmd.SilenceErrors = true
+		pruneCmd.SilenceUsage = true
+		pruneCmd.Flags().Set("delete", "true")
+
+		// Test
+		err := pruneCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `the project has no "name" in shore.yml, set a name unique to the project to prune its pipelines`)
+	})
+}
+
+func TestSuccessfulPruneCommandNoOrphans(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "shore.yml"), []byte(pruneShoreConfig), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(`{"application": "First Application"}`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(prunePipeline), os.ModePerm)
+		owner, _ := command.ProjectOwnership(deps, renderer.MainFileName, "default")
+
+		// Test
+		orphans, findErr := command.FindOrphanedPipelines(deps, `{"application": "First Application", "name": "Current Pipeline"}`, owner)
+
+		pruneCmd := command.NewPruneCommand(deps)
+		pruneCmd.SilenceErrors = true
+		pruneCmd.SilenceUsage = true
+		pruneCmd.Flags().Set("delete", "true")
+		err := pruneCmd.Execute()
+
+		// Assert
+		assert.Nil(t, findErr)
+		assert.Empty(t, orphans)
+		assert.Nil(t, err)
+	})
+}
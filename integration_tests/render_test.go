@@ -123,7 +123,7 @@ func TestFailedRenderMissingParam(t *testing.T) {
 func TestFailedRenderMissingAllRenderFileWithRequiredParams(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := "<top-level-arg:params>:1:1 Unexpected end of file\n\n\n\n"
+		expectedErrorMessage := "<top-level-arg:params>:1:1 Unexpected end of file\n"
 
 		pipeline := `
 		function(params={})(
@@ -151,7 +151,7 @@ func TestFailedRenderMissingAllRenderFileWithRequiredParams(t *testing.T) {
 func TestFailedMalformedPipeline(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := fmt.Sprintf("%v/main.pipeline.jsonnet:6:3-4 Unexpected: \")\" while parsing field definition\n\n\t\t)\n\n", testPath)
+		expectedErrorMessage := "main.pipeline.jsonnet:6:3 Unexpected: \")\" while parsing field definition\n\n6 | \t\t)\n  | \t\t^\n"
 
 		pipeline := `
 		function(params={})(
@@ -201,3 +201,73 @@ func TestFailedMalformedRenderFile(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSuccessfulRenderWithExtStrFlag(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		renderConfig := `{"a": "c"}`
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
+
+		pipeline := `
+		function(params={})(
+			{
+				first: params.a,
+				second: std.extVar("b")
+			}
+		)
+		`
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		renderCmd := command.NewRenderCommand(deps)
+		renderCmd.SilenceErrors = true
+		renderCmd.SilenceUsage = true
+		renderCmd.Flags().Set("ext-str", "b=d")
+		err := renderCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedRenderWithMalformedExtStrFlag(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		pipeline := `
+		function(params={})(
+			{
+				first: std.extVar("b")
+			}
+		)
+		`
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		renderCmd := command.NewRenderCommand(deps)
+		renderCmd.SilenceErrors = true
+		renderCmd.SilenceUsage = true
+		renderCmd.Flags().Set("ext-str", "b")
+		err := renderCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `invalid value "b" for the "extVars" renderer input, expected key=value`)
+	})
+}
+
+func TestFailedRenderWithMissingInterpolatedValue(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		os.Unsetenv("SHORE_TEST_MISSING_TOKEN")
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.yml"), []byte(`token: "${env:SHORE_TEST_MISSING_TOKEN}"`), os.ModePerm)
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(`function(params={}) { token: params.token }`), os.ModePerm)
+
+		// Test
+		renderCmd := command.NewRenderCommand(deps)
+		renderCmd.SilenceErrors = true
+		renderCmd.SilenceUsage = true
+		err := renderCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, `failed to resolve ${env:SHORE_TEST_MISSING_TOKEN} in /test/render.yml: environment variable "SHORE_TEST_MISSING_TOKEN" is not set`)
+	})
+}
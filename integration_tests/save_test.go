@@ -1,7 +1,6 @@
 package integration_tests
 
 import (
-	"fmt"
 	"os"
 	"path"
 	"testing"
@@ -43,7 +42,7 @@ func TestSuccessfulSaveWithConfigFile(t *testing.T) {
 func TestFailedSaveMissingParam(t *testing.T) {
 	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
 		// Given
-		expectedErrorMessage := fmt.Sprintf("RUNTIME ERROR: Field does not exist: pipeline\n\t%v/main.pipeline.jsonnet:5:11-26\tobject <anonymous>\n\tField \"name\"\t\n\tDuring manifestation\t\n", testPath)
+		expectedErrorMessage := "main.pipeline.jsonnet:5:11 Field does not exist: pipeline\n\n5 | \t\t\t\tname: params.pipeline\n  | \t\t\t\t      ^^^^^^^^^^^^^^^\n"
 
 		renderConfig := `{"application":"Fourth Application"}`
 		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
@@ -95,3 +94,109 @@ func TestSuccessSaveCommandLineRenderParams(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestSuccessfulSavePipelineSet(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		renderConfig := `{"application": "First Application", "regions": ["us-east-1", "eu-west-1"]}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
+
+		pipeline := `
+		function(params={})(
+			[
+				{
+					application: params.application,
+					name: "deploy-%s" % region
+				}
+				for region in params.regions
+			]
+		)
+		`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		saveCmd := command.NewSaveCommand(deps)
+		saveCmd.SilenceErrors = true
+		saveCmd.SilenceUsage = true
+		err := saveCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestSuccessfulSaveCrossApplicationNestedPipeline(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		renderConfig := `{"application": "platform", "pipeline": "Orchestrator"}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
+
+		pipeline := `
+		function(params={})(
+			{
+				application: params.application,
+				name: params.pipeline,
+				stages: [
+					{
+						application: 'team-a',
+						name: 'Deploy team A',
+						type: 'pipeline',
+						pipeline: {application: 'team-a', name: 'Deploy', stages: []},
+					},
+				],
+			}
+		)
+		`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		saveCmd := command.NewSaveCommand(deps)
+		saveCmd.SilenceErrors = true
+		saveCmd.SilenceUsage = true
+		err := saveCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedSaveMissingReferencedPipeline(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		renderConfig := `{"application": "First Application", "pipeline": "First Pipeline"}`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "render.json"), []byte(renderConfig), os.ModePerm)
+
+		pipeline := `
+		function(params={})(
+			{
+				application: params.application,
+				name: params.pipeline,
+				triggers: [{type: 'pipeline', application: 'not-exists', pipeline: 'Build'}],
+			}
+		)
+		`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "main.pipeline.jsonnet"), []byte(pipeline), os.ModePerm)
+
+		// Test
+		saveCmd := command.NewSaveCommand(deps)
+		saveCmd.SilenceErrors = true
+		saveCmd.SilenceUsage = true
+		err := saveCmd.Execute()
+
+		unboundSaveCmd := command.NewSaveCommand(deps)
+		unboundSaveCmd.SilenceErrors = true
+		unboundSaveCmd.SilenceUsage = true
+		unboundSaveCmd.Flags().Set("allow-unbound", "true")
+		unboundErr := unboundSaveCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "referenced pipelines don't exist: not-exists/Build\nuse `--create-missing` to create placeholder pipelines or `--allow-unbound` to save unbound references")
+		assert.Nil(t, unboundErr)
+	})
+}
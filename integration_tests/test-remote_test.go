@@ -454,3 +454,140 @@ func TestFailRemoteTestWithConfigFileWithIncorrectOrdering(t *testing.T) {
 		assert.Equal(t, execError, err.Error())
 	})
 }
+
+func TestFailedRemoteTestWithInvalidConfigFile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		e2eConfig := `application: cosv3-state-buckets
+pipeline: cosv3-dynamodb-table
+tests:
+  Test Success:
+    assertions:
+      testedname:
+        expected_status: suceeded
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "E2E.yml"), []byte(e2eConfig), os.ModePerm)
+
+		// Test
+		testRemoteCmd := command.NewTestRemoteCommand(deps)
+		testRemoteCmd.SilenceErrors = true
+		testRemoteCmd.SilenceUsage = true
+		err := testRemoteCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "invalid E2E configuration:\n\tE2E.yml:7: tests.Test Success.assertions.testedname.expected_status: unsupported value suceeded, expected one of: NOT_STARTED, RUNNING, PAUSED, SUSPENDED, SUCCEEDED, FAILED_CONTINUE, TERMINAL, CANCELED, REDIRECT, STOPPED, SKIPPED, BUFFERED")
+	})
+}
+
+func TestSuccessfulRemoteTestWithStatusListAndNot(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		e2eConfig := `application: cosv3-state-buckets
+pipeline: cosv3-dynamodb-table
+tests:
+  Test List:
+    assertions:
+      testedname:
+        expected_status: [skipped, Succeeded]
+  Test Not:
+    assertions:
+      testedname:
+        expected_status:
+          not: [terminal, canceled]
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "E2E.yml"), []byte(e2eConfig), os.ModePerm)
+
+		// Test
+		testRemoteCmd := command.NewTestRemoteCommand(deps)
+		testRemoteCmd.SilenceErrors = true
+		testRemoteCmd.SilenceUsage = true
+		err := testRemoteCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedRemoteTestWithNotStatus(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		e2eConfig := `application: cosv3-state-buckets
+pipeline: cosv3-dynamodb-table
+tests:
+  Test Not:
+    assertions:
+      testedname:
+        expected_status:
+          not: succeeded
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "E2E.yml"), []byte(e2eConfig), os.ModePerm)
+
+		// Test
+		testRemoteCmd := command.NewTestRemoteCommand(deps)
+		testRemoteCmd.SilenceErrors = true
+		testRemoteCmd.SilenceUsage = true
+		err := testRemoteCmd.Execute()
+
+		// Assert
+		assert.ErrorContains(t, err, "expected: 'not SUCCEEDED'")
+	})
+}
+
+func TestSuccessfulRemoteTestWithTrigger(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		e2eConfig := `application: triggered-app
+pipeline: cosv3-dynamodb-table
+tests:
+  Test Jenkins Build:
+    execution_args:
+      trigger:
+        type: jenkins
+        master: ci
+        job: build
+        buildNumber: 7
+    assertions:
+      testedname:
+        expected_status: succeeded
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "E2E.yml"), []byte(e2eConfig), os.ModePerm)
+
+		// Test
+		testRemoteCmd := command.NewTestRemoteCommand(deps)
+		testRemoteCmd.SilenceErrors = true
+		testRemoteCmd.SilenceUsage = true
+		err := testRemoteCmd.Execute()
+
+		// Assert
+		assert.Nil(t, err)
+	})
+}
+
+func TestFailedRemoteTestWithMissingRequiredParameter(t *testing.T) {
+	SetupTest(t, func(t *testing.T, deps *command.Dependencies) {
+		// Given
+		e2eConfig := `application: parameters-app
+pipeline: cosv3-dynamodb-table
+tests:
+  Test Defaults:
+    assertions:
+      testedname:
+        expected_status: succeeded
+`
+
+		afero.WriteFile(deps.Project.FS, path.Join(testPath, "E2E.yml"), []byte(e2eConfig), os.ModePerm)
+
+		// Test
+		testRemoteCmd := command.NewTestRemoteCommand(deps)
+		testRemoteCmd.SilenceErrors = true
+		testRemoteCmd.SilenceUsage = true
+		err := testRemoteCmd.Execute()
+
+		// Assert
+		assert.EqualError(t, err, "`Test Defaults`: invalid parameters for pipeline \"cosv3-dynamodb-table\":\n\tmissing required parameter \"region\"")
+	})
+}
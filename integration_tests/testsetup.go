@@ -26,9 +26,10 @@ func SetupTest(t *testing.T, f func(*testing.T, *command.Dependencies)) {
 	s := spinnaker.NewClient(logger)
 	s.CustomSpinCLI = &spinnaker.MockCustomSpinCli{}
 	s.SpinCLI = &spinnaker.SpinCLI{
-		ApplicationControllerAPI: &spinnaker.MockApplicationControllerAPI{},
-		PipelineControllerAPI:    &spinnaker.MockPipelineControllerAPI{},
-		Context:                  context.Background(),
+		ApplicationControllerAPI:    &spinnaker.MockApplicationControllerAPI{},
+		PipelineControllerAPI:       &spinnaker.MockPipelineControllerAPI{},
+		PipelineConfigControllerAPI: &spinnaker.MockPipelineConfigControllerAPI{},
+		Context:                     context.Background(),
 	}
 
 	deps := &command.Dependencies{
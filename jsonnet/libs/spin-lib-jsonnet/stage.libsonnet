@@ -345,21 +345,31 @@ local PipelineStage = Stage {
             ]
         }
 
+    @example
+        local myStage = NestedPipelineStage {
+            name: 'Call a pipeline of another team',
+            Parent: myParentPipeline,
+            Pipeline: myChildPipeline,
+            Application: 'other-team-app',
+        }
+
     @class
     @augments PipelineStage
 
     @property {Pipeline} Parent - The parent pipeline.
     @property {Pipeline} Pipeline - The child pipeline.
+    @property {String} [Application=Parent.application] - The Spinnaker Application of the child pipeline.
 **/
 local NestedPipelineStage = PipelineStage {
   Parent:: error '`Parent` (Object<Pipeline>) property is required for NestedPipelineStage',
   Pipeline:: error '`Pipeline` (Object<Pipeline>) property is required for NestedPipelineStage',
+  Application:: this.Parent.application,
 
   local this = self,
   local pipeline = this.Pipeline,
-  local innerPipeline = pipeline { application: this.Parent.application },
+  local innerPipeline = pipeline { application: this.Application },
 
-  application: this.Parent.application,
+  application: this.Application,
   pipeline: innerPipeline,
 };
 
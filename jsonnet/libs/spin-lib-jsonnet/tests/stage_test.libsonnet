@@ -19,6 +19,7 @@ local tests = [
   stage.CheckPreconditionsStage { name: 'my-check-precondition-stge', preconditions: [] },
   stage.RollbackClusterStage { name: 'my-rollback-cluster-stage', cluster: 'potato-cluster', credentials: 'creds', moniker: 'potatoes-dev', regions: ['us-west-2'] },
   stage.DestroyServerGroupStage { name: 'my-destroy-server-group-stage', cluster: 'potato-cluster', credentials: 'creds', regions: ['us-west-2'], target: 'current_asg_dynamic', cloudProvider: 'aws', cloudProviderType: 'aws' },
+  stage.NestedPipelineStage { name: 'my-cross-app-nested-pipeline-stage', Parent: { application: 'my-spinnaker-app' }, Pipeline: {}, Application: 'my-other-app' },
 ];
 
 local assertions = [
@@ -208,6 +209,19 @@ local assertions = [
     target: 'current_asg_dynamic',
     type: 'destroyServerGroup',
   },
+  {
+    application: 'my-other-app',
+    failPipeline: true,
+    name: 'my-cross-app-nested-pipeline-stage',
+    pipeline: {
+      application: 'my-other-app',
+    },
+    pipelineParameters: {},
+    refId: '',
+    requisiteStageRefIds: [],
+    type: 'pipeline',
+    waitForCompletion: true,
+  },
 ];
 
 {
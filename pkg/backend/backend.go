@@ -10,12 +10,75 @@ import (
 type Backend interface {
 	// TODO: Return type needs to be a custom wrapper in the future.
 	// We cannot assume that every backend-cli implementation will return an HTTP object.
-	SavePipeline(pipelineJSON string) (*http.Response, error)
+	SavePipeline(pipelineJSON string, options SaveOptions) (*http.Response, error)
 	ExecutePipeline(parameters string, stringify bool) (string, *http.Response, error)
 	WaitForPipelineToFinish(id string, timeout int) (string, *http.Response, error)
 	// TODO: Reconsider `onChange`, it may be a channel to communicate data between `shore-cli` & the Testing process in an async fashion.
 	TestPipeline(testConfig shore_testing.TestsConfig, onChange func(), stringify bool) error
 	GetPipeline(application string, pipelineName string) (map[string]interface{}, *http.Response, error)
 	DeletePipeline(pipelineJSON string) (*http.Response, error)
-	GetPipelinesNamesAndApplication(pipelineJSON string) ([]string, string, error)
+	GetPipelinesNamesByApplication(pipelineJSON string) ([]ApplicationPipelines, error)
+	ListPipelines(application string) ([]map[string]interface{}, error)
+	GetPipelineHistory(application string, pipelineName string, limit int) ([]map[string]interface{}, error)
+}
+
+// SaveOptions - How `SavePipeline` handles pipelines referenced by name (triggers, pipeline stages) that don't exist
+// and aren't part of the rendered output.
+//
+// By default saving fails, listing the missing pipelines.
+type SaveOptions struct {
+	// CreateMissing - Create empty placeholder pipelines for the missing pipelines before saving.
+	CreateMissing bool
+	// AllowUnbound - Save the references to the missing pipelines as `null` (unbound).
+	AllowUnbound bool
+	// Owner - Stamped on every saved pipeline (nested pipelines included), see `Ownership`.
+	Owner *Ownership
+}
+
+// ApplicationPipelines - The names of the pipelines of an application.
+type ApplicationPipelines struct {
+	Application string
+	Names       []string
+}
+
+// OwnershipKey - The pipeline key holding the `Ownership` of pipelines saved by shore.
+const OwnershipKey = "shore"
+
+// Ownership - Identifies the project (its source file & profile) that manages a pipeline.
+//
+// Used to find the pipelines of a project that are no longer rendered (renamed or removed), see `shore prune`.
+type Ownership struct {
+	// Project - The `name` of the project in shore.yml.
+	Project string `json:"project"`
+	Source  string `json:"source"`
+	// Profile - The profile the pipeline was rendered with, the profiles of a project may render other pipelines.
+	Profile string `json:"profile"`
+}
+
+// Stamp - Sets the ownership of a pipeline (as a plain JSON object).
+func (o *Ownership) Stamp(pipeline map[string]interface{}) {
+	pipeline[OwnershipKey] = map[string]interface{}{
+		"project": o.Project,
+		"source":  o.Source,
+		"profile": o.Profile,
+	}
+}
+
+// GetOwnership - The `Ownership` of a pipeline, `nil` when the pipeline isn't managed by shore.
+func GetOwnership(pipeline map[string]interface{}) *Ownership {
+	value, isMap := pipeline[OwnershipKey].(map[string]interface{})
+
+	if !isMap {
+		return nil
+	}
+
+	project, _ := value["project"].(string)
+	source, _ := value["source"].(string)
+	profile, _ := value["profile"].(string)
+
+	if project == "" {
+		return nil
+	}
+
+	return &Ownership{Project: project, Source: source, Profile: profile}
 }
@@ -0,0 +1,28 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipStamp(t *testing.T) {
+	// Given
+	pipeline := map[string]interface{}{"application": "app", "name": "pipeline"}
+	owner := &backend.Ownership{Project: "my-project", Source: "main", Profile: "prod"}
+
+	// Test
+	owner.Stamp(pipeline)
+
+	// Assert
+	assert.Equal(t, map[string]interface{}{"project": "my-project", "source": "main", "profile": "prod"}, pipeline[backend.OwnershipKey])
+	assert.Equal(t, owner, backend.GetOwnership(pipeline))
+}
+
+func TestGetOwnershipUnmanagedPipeline(t *testing.T) {
+	// Test & Assert
+	assert.Nil(t, backend.GetOwnership(map[string]interface{}{"application": "app", "name": "pipeline"}))
+	assert.Nil(t, backend.GetOwnership(map[string]interface{}{"shore": "not-an-object"}))
+	assert.Nil(t, backend.GetOwnership(map[string]interface{}{"shore": map[string]interface{}{"source": "main"}}))
+}
@@ -0,0 +1,73 @@
+package backend
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// PipelinesKey - the top level key a renderer may use to output a set of unrelated pipelines.
+const PipelinesKey = "pipelines"
+
+// ParsePipelines - Parses a rendered output into the list of pipelines it describes.
+//
+// A rendered output may be one of:
+//   - A single pipeline object - `{"application": "app", "name": "pipeline", ...}`
+//   - A top-level array of pipelines - `[{...}, {...}]`
+//   - An object holding an array of pipelines - `{"pipelines": [{...}, {...}]}`
+//
+// The boolean return value is `true` when the output is a set of pipelines (the two latter forms).
+func ParsePipelines(pipelineJSON string) ([]map[string]interface{}, bool, error) {
+	var rendered interface{}
+
+	if err := jsoniter.Unmarshal([]byte(pipelineJSON), &rendered); err != nil {
+		return nil, false, err
+	}
+
+	switch value := rendered.(type) {
+	case []interface{}:
+		pipelines, err := toPipelines(value)
+		return pipelines, true, err
+	case map[string]interface{}:
+		if set, exists := value[PipelinesKey]; exists && !isPipeline(value) {
+			setSlice, ok := set.([]interface{})
+
+			if !ok {
+				return nil, true, fmt.Errorf("the `%s` key must be an array of pipelines", PipelinesKey)
+			}
+
+			pipelines, err := toPipelines(setSlice)
+			return pipelines, true, err
+		}
+
+		return []map[string]interface{}{value}, false, nil
+	}
+
+	return nil, false, fmt.Errorf("the rendered output must be a pipeline object or an array of pipelines")
+}
+
+func toPipelines(values []interface{}) ([]map[string]interface{}, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("the rendered set of pipelines is empty")
+	}
+
+	pipelines := make([]map[string]interface{}, 0, len(values))
+
+	for i, value := range values {
+		pipeline, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("pipeline at index %d of the rendered set must be an object", i)
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+// A pipeline object always has a `name`, a set object never does.
+func isPipeline(value map[string]interface{}) bool {
+	_, exists := value["name"]
+	return exists
+}
@@ -0,0 +1,65 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePipelinesSinglePipeline(t *testing.T) {
+	// Test
+	pipelines, isSet, err := backend.ParsePipelines(`{"application": "app", "name": "pipeline"}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.False(t, isSet)
+	assert.Len(t, pipelines, 1)
+	assert.Equal(t, "pipeline", pipelines[0]["name"])
+}
+
+func TestParsePipelinesArray(t *testing.T) {
+	// Test
+	pipelines, isSet, err := backend.ParsePipelines(`[{"application": "app", "name": "us-east-1"}, {"application": "app", "name": "eu-west-1"}]`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, isSet)
+	assert.Len(t, pipelines, 2)
+	assert.Equal(t, "us-east-1", pipelines[0]["name"])
+	assert.Equal(t, "eu-west-1", pipelines[1]["name"])
+}
+
+func TestParsePipelinesObjectSet(t *testing.T) {
+	// Test
+	pipelines, isSet, err := backend.ParsePipelines(`{"pipelines": [{"application": "app", "name": "us-east-1"}]}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, isSet)
+	assert.Len(t, pipelines, 1)
+}
+
+func TestParsePipelinesEmptySetFails(t *testing.T) {
+	// Test
+	_, _, err := backend.ParsePipelines(`[]`)
+
+	// Assert
+	assert.EqualError(t, err, "the rendered set of pipelines is empty")
+}
+
+func TestParsePipelinesNonObjectFails(t *testing.T) {
+	// Test
+	_, _, err := backend.ParsePipelines(`[{"application": "app", "name": "pipeline"}, "pipeline"]`)
+
+	// Assert
+	assert.EqualError(t, err, "pipeline at index 1 of the rendered set must be an object")
+}
+
+func TestParsePipelinesScalarFails(t *testing.T) {
+	// Test
+	_, _, err := backend.ParsePipelines(`"pipeline"`)
+
+	// Assert
+	assert.EqualError(t, err, "the rendered output must be a pipeline object or an array of pipelines")
+}
@@ -12,7 +12,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"math"
 	"net/http"
 	"reflect"
@@ -21,7 +20,9 @@ import (
 	"time"
 
 	"github.com/Autodesk/shore/internal/retry"
+	"github.com/Autodesk/shore/pkg/backend"
 	"github.com/Autodesk/shore/pkg/shore_testing"
+	"github.com/antihax/optional"
 	"github.com/fatih/color"
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
@@ -36,6 +37,7 @@ const defaultTestTimeout = 1200 // 20 minutes in seconds
 // ApplicationControllerAPI - Interface wrapper for the Application Controller API
 type ApplicationControllerAPI interface {
 	GetPipelineConfigUsingGET(ctx context.Context, application string, pipelineName string) (map[string]interface{}, *http.Response, error)
+	GetPipelineConfigsForApplicationUsingGET(ctx context.Context, application string) ([]interface{}, *http.Response, error)
 }
 
 type TestPipelineResponse struct {
@@ -53,10 +55,16 @@ type PipelineControllerAPI interface {
 	DeletePipelineUsingDELETE(ctx context.Context, application string, pipelineName string) (*http.Response, error)
 }
 
+// PipelineConfigControllerAPI - Interface wrapper for the Pipeline Config Controller API
+type PipelineConfigControllerAPI interface {
+	GetPipelineConfigHistoryUsingGET(ctx context.Context, pipelineConfigID string, localVarOptionals *spinGateApi.PipelineConfigControllerApiGetPipelineConfigHistoryUsingGETOpts) ([]interface{}, *http.Response, error)
+}
+
 // SpinCLI is a wrapper for the spin-cli gateway client backed by swagger
 type SpinCLI struct {
 	ApplicationControllerAPI
 	PipelineControllerAPI
+	PipelineConfigControllerAPI
 	context.Context
 }
 
@@ -85,14 +93,79 @@ func (s *SpinClient) GetPipeline(application string, pipelineName string) (map[s
 
 	pipeline, res, err := s.ApplicationControllerAPI.GetPipelineConfigUsingGET(s.Context, application, pipelineName)
 
+	// The response is returned with the error, I.E. to tell a missing pipeline (404) from other errors.
 	if err != nil {
 		outErr = err
-		return nil, nil, outErr
+		return nil, res, outErr
 	}
 
 	return pipeline, res, err
 }
 
+// ListPipelines - The configurations of all the pipelines of an application.
+func (s *SpinClient) ListPipelines(application string) ([]map[string]interface{}, error) {
+	if err := s.initializeAPI(); err != nil {
+		return nil, err
+	}
+
+	configs, res, err := s.ApplicationControllerAPI.GetPipelineConfigsForApplicationUsingGET(s.Context, application)
+
+	if err != nil {
+		return nil, NewApplicationControllerError(err, res)
+	}
+
+	pipelines := make([]map[string]interface{}, 0, len(configs))
+
+	for _, config := range configs {
+		if pipeline, isMap := config.(map[string]interface{}); isMap {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+
+	return pipelines, nil
+}
+
+// GetPipelineHistory - The saved revisions of a pipeline configuration, newest (the current configuration) first.
+//
+// A `limit` of 0 uses the backend's default, a pipeline that doesn't exist has no revisions.
+func (s *SpinClient) GetPipelineHistory(application string, pipelineName string, limit int) ([]map[string]interface{}, error) {
+	if err := s.initializeAPI(); err != nil {
+		return nil, err
+	}
+
+	pipelineID, _, err := s.getOtherPipelineId(application, pipelineName)
+
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if pipelineID == "" {
+		return []map[string]interface{}{}, nil
+	}
+
+	var opts *spinGateApi.PipelineConfigControllerApiGetPipelineConfigHistoryUsingGETOpts
+
+	if limit > 0 {
+		opts = &spinGateApi.PipelineConfigControllerApiGetPipelineConfigHistoryUsingGETOpts{Limit: optional.NewInt32(int32(limit))}
+	}
+
+	configs, res, err := s.PipelineConfigControllerAPI.GetPipelineConfigHistoryUsingGET(s.Context, pipelineID, opts)
+
+	if err != nil {
+		return nil, NewApplicationControllerError(err, res)
+	}
+
+	revisions := make([]map[string]interface{}, 0, len(configs))
+
+	for _, config := range configs {
+		if revision, isMap := config.(map[string]interface{}); isMap {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	return revisions, nil
+}
+
 // NewClient - Create a new default spinnaker client
 func NewClient(logger logrus.FieldLogger) *SpinClient {
 	return &SpinClient{log: logger}
@@ -123,9 +196,10 @@ func (s *SpinClient) initializeAPI() error {
 
 			s.CustomSpinCLI = &CustomSpinClient{Endpoint: gateClient.Config.Gate.Endpoint, HTTPClient: httpClient}
 			s.SpinCLI = &SpinCLI{
-				ApplicationControllerAPI: gateClient.ApplicationControllerApi,
-				PipelineControllerAPI:    gateClient.PipelineControllerApi,
-				Context:                  gateClient.Context,
+				ApplicationControllerAPI:    gateClient.ApplicationControllerApi,
+				PipelineControllerAPI:       gateClient.PipelineControllerApi,
+				PipelineConfigControllerAPI: gateClient.PipelineConfigControllerApi,
+				Context:                     gateClient.Context,
 			}
 		})
 	}
@@ -151,10 +225,33 @@ func mapContainsKey(mapInput map[string]interface{}, searchString string) bool {
 	return found
 }
 
-func (s *SpinClient) findAndReplacePipelineNameWithFoundID(spinnakerObject map[string]interface{}) (bool, map[string]interface{}) {
+// findAndReplacePipelineNameWithFoundID - Replaces the pipeline name of a trigger or a stage with the pipeline ID.
+//
+// A reference to a pipeline that doesn't exist is an error, unless `allowUnbound` is set (the reference is set to `null`).
+// Unbound (`null`) references, I.E. of a restored pipeline, are kept when `allowUnbound` is set.
+func (s *SpinClient) findAndReplacePipelineNameWithFoundID(spinnakerObject map[string]interface{}, allowUnbound bool) (bool, map[string]interface{}, error) {
 	s.log.WithFields(logrus.Fields{"spinnaker_object": spinnakerObject}).Info("Found spinnaker object with 'application' and 'pipeline' name fields")
-	pipelineApp := spinnakerObject["application"].(string)
-	pipelineName := spinnakerObject["pipeline"].(string)
+	pipelineApp, isAppString := spinnakerObject["application"].(string)
+
+	if !isAppString {
+		return false, spinnakerObject, fmt.Errorf("the application of a pipeline reference must be a string, got %v", spinnakerObject["application"])
+	}
+
+	if spinnakerObject["pipeline"] == nil {
+		if !allowUnbound {
+			return false, spinnakerObject, fmt.Errorf("a pipeline reference of application %q is unbound (`null`), use `--allow-unbound` to save unbound references", pipelineApp)
+		}
+
+		s.log.WithFields(logrus.Fields{"application": pipelineApp}).Warn("The pipeline reference is unbound")
+
+		return false, spinnakerObject, nil
+	}
+
+	pipelineName, isNameString := spinnakerObject["pipeline"].(string)
+
+	if !isNameString {
+		return false, spinnakerObject, fmt.Errorf("a pipeline reference of application %q must be a pipeline name or ID, got %v", pipelineApp, spinnakerObject["pipeline"])
+	}
 
 	isPipelineUUID, err := isValidv4UUIDtypeRFC4122(pipelineName)
 	isPipelineSpEL := isSpEL(pipelineName)
@@ -164,27 +261,36 @@ func (s *SpinClient) findAndReplacePipelineNameWithFoundID(spinnakerObject map[s
 			"uuid_error":    err,
 		}).Info("Checking if provided pipeline name is not already a valid pipeline UUID or SpEL expression, looking for existing pipeline.")
 
-		newID, res, err := s.getOtherPipelineId(pipelineApp, pipelineName)
+		newID, _, err := s.getOtherPipelineId(pipelineApp, pipelineName)
 		newSpinnakerObject := spinnakerObject
 
-		if err != nil && res.StatusCode == 404 {
+		if err != nil && !isNotFoundError(err) {
+			return false, spinnakerObject, err
+		}
+
+		if newID == "" {
+			if !allowUnbound {
+				return false, spinnakerObject, fmt.Errorf("referenced pipeline %q doesn't exist in application %q, use `--allow-unbound` to save unbound references", pipelineName, pipelineApp)
+			}
+
 			s.log.WithFields(logrus.Fields{
 				"pipeline_name": pipelineName,
 				"application":   pipelineApp,
-				"status code":   res.StatusCode,
-			}).Warn("Failed to find a matching pipeline")
+			}).Warn("Failed to find a matching pipeline, the reference is unbound")
 			newSpinnakerObject["pipeline"] = nil
-		} else {
-			newSpinnakerObject["pipeline"] = newID
+
+			return true, newSpinnakerObject, nil
 		}
 
+		newSpinnakerObject["pipeline"] = newID
+
 		s.log.WithFields(logrus.Fields{
 			"pipeline_name": pipelineName,
 			"pipeline_id":   newID,
 			"application":   pipelineApp,
 		}).Info("Replacing pipeline name with valid pipeline UUID from specified application.")
 
-		return true, newSpinnakerObject
+		return true, newSpinnakerObject, nil
 	} else {
 		s.log.WithFields(logrus.Fields{
 			"pipeline_name": pipelineName,
@@ -192,7 +298,36 @@ func (s *SpinClient) findAndReplacePipelineNameWithFoundID(spinnakerObject map[s
 		}).Info("Provided pipeline name is already a valid pipeline UUID or a SpEL expression")
 	}
 
-	return false, spinnakerObject
+	return false, spinnakerObject, nil
+}
+
+// getPipelineConfig - The configuration of a pipeline, empty when the pipeline doesn't exist (`404 Not Found`).
+func (s *SpinClient) getPipelineConfig(application string, pipelineName string) (map[string]interface{}, error) {
+	pipeline, res, err := s.GetPipeline(application, pipelineName)
+
+	if err == nil {
+		return pipeline, nil
+	}
+
+	// No response, I.E. the API couldn't be initialized.
+	if res == nil {
+		return nil, err
+	}
+
+	wrappedErr := NewApplicationControllerError(err, res)
+
+	if wrappedErr.StatusCode() == http.StatusNotFound {
+		return map[string]interface{}{}, nil
+	}
+
+	return nil, wrappedErr
+}
+
+// isNotFoundError - Whether an error is a `404 Not Found` response of the Application Controller.
+func isNotFoundError(err error) bool {
+	var controllerErr *ApplicationControllerError
+
+	return errors.As(err, &controllerErr) && controllerErr.response != nil && controllerErr.StatusCode() == http.StatusNotFound
 }
 
 func isValidv4UUIDtypeRFC4122(u string) (bool, error) {
@@ -214,7 +349,9 @@ func isSpEL(u string) bool {
 }
 
 // TODO: We have to implement transaction based saving everywhere - at the moment if something goes off the state is undefined.
-func (s *SpinClient) savePipeline(pipelineJSON string) (string, *http.Response, error) {
+//
+// The pipeline is stamped with `owner` (when set), see `backend.Ownership`.
+func (s *SpinClient) savePipeline(pipelineJSON string, owner *backend.Ownership) (string, *http.Response, error) {
 	var pipeline map[string]interface{}
 	pipelineID := ""
 
@@ -242,6 +379,10 @@ func (s *SpinClient) savePipeline(pipelineJSON string) (string, *http.Response,
 		pipeline["type"] = "templatedPipeline"
 	}
 
+	if owner != nil {
+		owner.Stamp(pipeline)
+	}
+
 	foundPipeline, queryResp, err := s.ApplicationControllerAPI.GetPipelineConfigUsingGET(s.Context, application, pipelineName)
 	if err != nil {
 		wrappedErr := NewApplicationControllerError(err, queryResp)
@@ -270,7 +411,7 @@ func (s *SpinClient) savePipeline(pipelineJSON string) (string, *http.Response,
 
 // ExecutePipeline - Execute a spinnaker pipeline.
 //
-// `patameters` are optional.
+// `patameters`, `artifacts` & `trigger` are optional.
 func (s *SpinClient) ExecutePipeline(argsJSON string, stringify bool) (string, *http.Response, error) {
 	// For some crazy reason, spincli invoke doesn't return the ID of the pipeline execution.
 	// BTW the crazy reason is that `swagger-code-gen` produces wrong code and Spin-Cli (and shore...) depends on this wrong code.
@@ -296,6 +437,12 @@ func (s *SpinClient) ExecutePipeline(argsJSON string, stringify bool) (string, *
 	application := args["application"].(string)
 	pipelineName := args["pipeline"].(string)
 
+	pipeline, err := s.getPipelineConfig(application, pipelineName)
+
+	if err != nil {
+		return "", &http.Response{}, err
+	}
+
 	// The Spinnaker API is very weird in terms of JSON
 	// Sending JSON as is just kills the request (400) status code.
 	// However, if the JSON is stringified (example {"a": "a"} -> "{\"a\": \"a\"}")
@@ -303,10 +450,15 @@ func (s *SpinClient) ExecutePipeline(argsJSON string, stringify bool) (string, *
 	// This is due to the fact that the parameters API can only handle scalar values (string, int, bool)
 	// This logic checks if a pipeline parameter looks like: {"key": "value"}, ["key"], [{"key": "value"}]
 	// If the value is of one of the example types, the algorithm will stringify the property before the request is sent.
-	if params, exists := args["parameters"]; exists {
-		if reflect.TypeOf(params).Kind() != reflect.Map {
-			return "", &http.Response{}, fmt.Errorf("`parameters` must be an object")
-		}
+	if params, exists := args["parameters"]; exists && reflect.TypeOf(params).Kind() != reflect.Map {
+		return "", &http.Response{}, fmt.Errorf("`parameters` must be an object")
+	}
+
+	if err = validateParameters(pipelineName, pipeline, args); err != nil {
+		return "", &http.Response{}, err
+	}
+
+	if _, exists := args["parameters"]; exists {
 		if stringify {
 			parameters := args["parameters"].(map[string]interface{})
 
@@ -338,6 +490,27 @@ func (s *SpinClient) ExecutePipeline(argsJSON string, stringify bool) (string, *
 		}
 	}
 
+	// The execution request is the trigger of the execution, the `trigger` section is flattened into it.
+	if triggerValue, exists := args["trigger"]; exists {
+		trigger, err := parseTrigger(triggerValue)
+
+		if err != nil {
+			return "", &http.Response{}, err
+		}
+
+		payload, err := s.triggerPayload(application, pipelineName, pipeline, trigger)
+
+		if err != nil {
+			return "", &http.Response{}, err
+		}
+
+		delete(args, "trigger")
+
+		for key, value := range payload {
+			args[key] = value
+		}
+	}
+
 	delete(args, "application")
 	delete(args, "pipeline")
 
@@ -398,6 +571,10 @@ func (s *SpinClient) TestPipeline(testConfig shore_testing.TestsConfig, onChange
 		testsToRun = configuredTestNames
 	}
 
+	if err := s.validateTestsParameters(testConfig, testsToRun); err != nil {
+		return err
+	}
+
 	// TODO: Rethink the channel size (https://github.com/Autodesk/shore/pull/200#discussion_r2847971)
 	var ch = make(chan *TestPipelineResponse, len(testsToRun))
 	var wg = sync.WaitGroup{}
@@ -429,6 +606,39 @@ func (s *SpinClient) TestPipeline(testConfig shore_testing.TestsConfig, onChange
 	return nil
 }
 
+// validateTestsParameters - Validates the parameters of all the tests before any of them is executed.
+func (s *SpinClient) validateTestsParameters(testConfig shore_testing.TestsConfig, testsToRun []string) error {
+	pipeline, err := s.getPipelineConfig(testConfig.Application, testConfig.Pipeline)
+
+	if err != nil {
+		return err
+	}
+
+	var errorsList []string
+
+	for _, testName := range testsToRun {
+		// Copied, the defaults are applied by `ExecutePipeline`.
+		parameters, err := parametersObject(testConfig.Tests[testName].ExecArgs["parameters"])
+
+		if err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("`%s`: %s", testName, err))
+			continue
+		}
+
+		args := map[string]interface{}{"parameters": parameters}
+
+		if err := validateParameters(testConfig.Pipeline, pipeline, args); err != nil {
+			errorsList = append(errorsList, fmt.Sprintf("`%s`: %s", testName, err))
+		}
+	}
+
+	if len(errorsList) > 0 {
+		return errors.New(strings.Join(errorsList, "\n"))
+	}
+
+	return nil
+}
+
 func (s *SpinClient) RunTest(testName string, testConfig shore_testing.TestsConfig, testErrors map[string][]string, stringify bool) *TestPipelineResponse {
 
 	s.log.Info(fmt.Sprintf("Running test %s", testName))
@@ -486,9 +696,7 @@ func (s *SpinClient) ValidateTestResponses(testResponse TestPipelineResponse, ti
 			continue
 		}
 
-		expectedStatus := strings.ToUpper(assertion.ExpectedStatus)
-
-		if err := isExpectedStatus(expectedStatus, stage["status"].(string), stageName); err != nil {
+		if err := isExpectedStatus(assertion.ExpectedStatus, stage["status"].(string), stageName); err != nil {
 			testErrors[testResponse.testName] = append(testErrors[testResponse.testName], err.Error())
 		}
 
@@ -603,17 +811,9 @@ func (s *SpinClient) isArgsValid(args map[string]interface{}) error {
 	return nil
 }
 
-func isValidPipelineApplication(pipeline map[string]interface{}, parentPipelineApp []string) error {
-	if len(parentPipelineApp) > 0 {
-		if parentApplication := parentPipelineApp[0]; pipeline["application"].(string) != parentApplication {
-			return fmt.Errorf("pipeline 'application' key value should match the value of parent pipeline 'application' key")
-		}
-	}
-
-	return nil
-}
-
-func hasValidChildPipelineStages(stages []interface{}, parentPipelineApp []string) (bool, error) {
+// hasValidChildPipelineStages - Validates the pipeline stages, a child pipeline may belong to any application
+// but must belong to the application of its stage.
+func hasValidChildPipelineStages(stages []interface{}) (bool, error) {
 	var errorsList []string
 	hasPipelineStages := false
 
@@ -633,10 +833,12 @@ func hasValidChildPipelineStages(stages []interface{}, parentPipelineApp []strin
 				continue
 			}
 		}
-		// Application value should match the one with the parent pipeline's one
+		// Application value should match the one of the child pipeline
 		if pipeline, exists := stage.(map[string]interface{})["pipeline"]; exists {
+			childPipeline, isMap := pipeline.(map[string]interface{})
 
-			if reflect.TypeOf(pipeline).Kind() != reflect.Map {
+			// A pipeline name, ID or an unbound (`null`) reference.
+			if !isMap {
 				continue
 			}
 
@@ -644,12 +846,8 @@ func hasValidChildPipelineStages(stages []interface{}, parentPipelineApp []strin
 			stageApplication, applicationExists := stage.(map[string]interface{})["application"]
 			if !applicationExists {
 				errorsList = append(errorsList, "required stage key 'application' missing for stage")
-			} else {
-				if len(parentPipelineApp) > 0 {
-					if parentApplication := parentPipelineApp[0]; stageApplication.(string) != parentApplication {
-						errorsList = append(errorsList, "'application' key value of stage of type 'pipeline' should match the one of parent pipeline 'application' value")
-					}
-				}
+			} else if childApplication, exists := childPipeline["application"]; exists && childApplication != stageApplication {
+				errorsList = append(errorsList, "'application' key value of stage of type 'pipeline' should match the 'application' key value of its child pipeline")
 			}
 
 		}
@@ -699,7 +897,7 @@ func (s *SpinClient) pollSpinnakerGetPipelineConfigUsingGET(application string,
 // It's pipeline UUID is assigned to the parent pipeline relevant stage's "pipeline" key
 // The parent loop continues until all its stages of type pipeline are updated with pipeline UUIDs and it is saved.
 // Once all loops are closed the most top level pipeline has all all stage's pipelines replaced with UUIDs and it is saved
-func (s *SpinClient) saveNestedPipeline(stages interface{}, pipeline map[string]interface{}) error {
+func (s *SpinClient) saveNestedPipeline(stages interface{}, options backend.SaveOptions) error {
 	for _, stage := range stages.([]interface{}) {
 		stage := stage.(map[string]interface{})
 		stagePipelineField, exists := stage["pipeline"]
@@ -711,34 +909,27 @@ func (s *SpinClient) saveNestedPipeline(stages interface{}, pipeline map[string]
 			continue
 		}
 
-		switch stagePipelineField.(type) {
-		case string:
+		// A pipeline name, ID or an unbound (`null`) reference.
+		childPipeline, isMap := stagePipelineField.(map[string]interface{})
+		if !isMap {
 			continue
 		}
 
-		childPipeline := stage["pipeline"].(map[string]interface{})
-
 		if err := s.isValidPipeline(childPipeline); err != nil {
 			return err
 		}
 
-		parentPipelineApplication := []string{pipeline["application"].(string)}
-
-		if err := isValidPipelineApplication(childPipeline, parentPipelineApplication); err != nil {
-			return err
-		}
-
 		childPipelineStages, exists := childPipeline["stages"]
 		if exists {
 
-			hasChildPipelines, err := hasValidChildPipelineStages(childPipelineStages.([]interface{}), parentPipelineApplication)
+			hasChildPipelines, err := hasValidChildPipelineStages(childPipelineStages.([]interface{}))
 			if err != nil {
 				return err
 			}
 
 			// If any of stages is of type pipeline create those pipelines recursively
 			if hasChildPipelines {
-				if err := s.saveNestedPipeline(childPipelineStages, childPipeline); err != nil {
+				if err := s.saveNestedPipeline(childPipelineStages, options); err != nil {
 					return err
 				}
 			}
@@ -749,8 +940,8 @@ func (s *SpinClient) saveNestedPipeline(stages interface{}, pipeline map[string]
 				innerStage := stage.(map[string]interface{})
 
 				if mapContainsKey(innerStage, "application") && mapContainsKey(innerStage, "pipeline") && reflect.TypeOf(innerStage["pipeline"]).Kind() == reflect.String {
-					if response, result := s.findAndReplacePipelineNameWithFoundID(innerStage); response {
-						stage = result
+					if _, _, err := s.findAndReplacePipelineNameWithFoundID(innerStage, options.AllowUnbound); err != nil {
+						return err
 					}
 				}
 			}
@@ -762,7 +953,7 @@ func (s *SpinClient) saveNestedPipeline(stages interface{}, pipeline map[string]
 			return err
 		}
 
-		pipelineID, res, err := s.savePipeline(string(childPipelineBytes))
+		pipelineID, res, err := s.savePipeline(string(childPipelineBytes), options.Owner)
 		if err != nil {
 			return err
 		}
@@ -789,33 +980,71 @@ func (s *SpinClient) saveNestedPipeline(stages interface{}, pipeline map[string]
 }
 
 // SavePipeline - Creates or Update nested pipelines recursively
-func (s *SpinClient) SavePipeline(pipelineJSON string) (*http.Response, error) {
+//
+// When the rendered output is a set of pipelines, the pipelines are saved in dependency order,
+// pipelines referenced by name from triggers or pipeline stages are saved first.
+//
+// Pipelines referenced by name that aren't rendered must exist before anything is saved,
+// see `backend.SaveOptions` for creating them or saving unbound references.
+func (s *SpinClient) SavePipeline(pipelineJSON string, options backend.SaveOptions) (*http.Response, error) {
 
 	if err := s.initializeAPI(); err != nil {
 		return &http.Response{}, err
 	}
 
-	var pipeline map[string]interface{}
+	pipelines, _, err := backend.ParsePipelines(pipelineJSON)
 
-	err := jsoniter.Unmarshal([]byte(pipelineJSON), &pipeline)
+	if err != nil {
+		return &http.Response{}, err
+	}
+
+	for _, pipeline := range pipelines {
+		if err := s.isValidPipeline(pipeline); err != nil {
+			return &http.Response{}, err
+		}
+	}
+
+	pipelines, err = sortPipelinesByDependencies(pipelines)
 
 	if err != nil {
-		log.Fatal(err)
+		return &http.Response{}, err
 	}
 
-	if err := s.isValidPipeline(pipeline); err != nil {
+	if err := s.resolveMissingReferences(pipelines, options); err != nil {
 		return &http.Response{}, err
 	}
 
+	var res *http.Response
+
+	for _, pipeline := range pipelines {
+		s.log.Infof("Saving pipeline %q", getPipelineKey(pipeline))
+
+		if res, err = s.saveRenderedPipeline(pipeline, options); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// saveRenderedPipeline - Creates or Update a single rendered pipeline and its nested pipelines recursively.
+func (s *SpinClient) saveRenderedPipeline(pipeline map[string]interface{}, options backend.SaveOptions) (*http.Response, error) {
 	s.log.Info("Searching for Triggers with PipelineID needing replacement")
 
 	if triggers, exists := pipeline["triggers"]; exists {
 		//  Replace upstream pipeline name string with real pipeline ID
-		for _, trigger := range triggers.([]interface{}) {
-			triggerObj := trigger.(map[string]interface{})
+		triggersSlice, _ := triggers.([]interface{})
+
+		for _, trigger := range triggersSlice {
+			triggerObj, isMap := trigger.(map[string]interface{})
+
+			if !isMap {
+				continue
+			}
+
 			if mapContainsKey(triggerObj, "application") && mapContainsKey(triggerObj, "pipeline") {
-				if response, result := s.findAndReplacePipelineNameWithFoundID(triggerObj); response {
-					trigger = result
+				if _, _, err := s.findAndReplacePipelineNameWithFoundID(triggerObj, options.AllowUnbound); err != nil {
+					return &http.Response{}, err
 				}
 			}
 		}
@@ -830,23 +1059,24 @@ func (s *SpinClient) SavePipeline(pipelineJSON string) (*http.Response, error) {
 			stage := stage.(map[string]interface{})
 
 			if mapContainsKey(stage, "application") && mapContainsKey(stage, "pipeline") {
-				pipelineDataType := reflect.TypeOf(stage["pipeline"])
-				if pipelineDataType.Kind() == reflect.String {
-					if response, result := s.findAndReplacePipelineNameWithFoundID(stage); response {
-						stage = result
+				// Nested pipeline objects are saved with `saveNestedPipeline`.
+				switch stage["pipeline"].(type) {
+				case string, nil:
+					if _, _, err := s.findAndReplacePipelineNameWithFoundID(stage, options.AllowUnbound); err != nil {
+						return &http.Response{}, err
 					}
 				}
 			}
 		}
 
-		hasChildPipelines, err := hasValidChildPipelineStages(stages.([]interface{}), []string{pipeline["application"].(string)})
+		hasChildPipelines, err := hasValidChildPipelineStages(stages.([]interface{}))
 		if err != nil {
 			return &http.Response{}, err
 		}
 
 		// If any of stages is of type pipeline create those pipelines recursively
 		if hasChildPipelines {
-			if err := s.saveNestedPipeline(stages, pipeline); err != nil {
+			if err := s.saveNestedPipeline(stages, options); err != nil {
 				return &http.Response{}, err
 			}
 		}
@@ -857,7 +1087,7 @@ func (s *SpinClient) SavePipeline(pipelineJSON string) (*http.Response, error) {
 		return &http.Response{}, err
 	}
 
-	pipelineID, res, err := s.savePipeline(string(pipelineBytes))
+	pipelineID, res, err := s.savePipeline(string(pipelineBytes), options.Owner)
 	if err != nil {
 		return &http.Response{}, err
 	}
@@ -876,8 +1106,8 @@ func (s *SpinClient) SavePipeline(pipelineJSON string) (*http.Response, error) {
 // Once a pipeline with no "pipeline" stages is met - it is deleted
 // The parent loop continues until all its stages of type pipeline are deleted.
 // Once all loops are closed the most top level pipeline gets deleted.
-func (s *SpinClient) getNestedPipelinesNames(stages interface{}, pipeline map[string]interface{}) ([]string, error) {
-	pipelineNames := []string{}
+func (s *SpinClient) getNestedPipelinesNames(stages interface{}) ([]pipelineKey, error) {
+	pipelineNames := []pipelineKey{}
 	for _, stage := range stages.([]interface{}) {
 		stage := stage.(map[string]interface{})
 		stagePipelineField, exists := stage["pipeline"]
@@ -890,33 +1120,26 @@ func (s *SpinClient) getNestedPipelinesNames(stages interface{}, pipeline map[st
 			continue
 		}
 
-		switch stagePipelineField.(type) {
-		case string:
+		// A pipeline name, ID or an unbound (`null`) reference.
+		childPipeline, isMap := stagePipelineField.(map[string]interface{})
+		if !isMap {
 			continue
 		}
 
-		childPipeline := stage["pipeline"].(map[string]interface{})
-
 		if err := s.isValidPipeline(childPipeline); err != nil {
 			return pipelineNames, err
 		}
 
-		parentPipelineApplication := []string{pipeline["application"].(string)}
-
-		if err := isValidPipelineApplication(childPipeline, parentPipelineApplication); err != nil {
-			return pipelineNames, err
-		}
-
 		childPipelineStages, exists := childPipeline["stages"]
 		if exists {
-			hasChildPipelines, err := hasValidChildPipelineStages(childPipelineStages.([]interface{}), parentPipelineApplication)
+			hasChildPipelines, err := hasValidChildPipelineStages(childPipelineStages.([]interface{}))
 			if err != nil {
 				return pipelineNames, err
 			}
 
 			// If any of stages is of type pipeline get those pipelines names recursively
 			if hasChildPipelines {
-				nestedPipelineNames, err := s.getNestedPipelinesNames(childPipelineStages, childPipeline)
+				nestedPipelineNames, err := s.getNestedPipelinesNames(childPipelineStages)
 				if err != nil {
 					return pipelineNames, err
 				}
@@ -925,75 +1148,82 @@ func (s *SpinClient) getNestedPipelinesNames(stages interface{}, pipeline map[st
 		}
 
 		// After we return from recursion we add "this layer" child pipeline
-		childPipelineName := childPipeline["name"].(string)
-		pipelineNames = append(pipelineNames, childPipelineName)
+		pipelineNames = append(pipelineNames, getPipelineKey(childPipeline))
 	}
 
 	return pipelineNames, nil
 }
 
-func (s *SpinClient) getPipelinesNames(pipeline map[string]interface{}) ([]string, error) {
-	pipelineNames := []string{}
+func (s *SpinClient) getPipelinesNames(pipeline map[string]interface{}) ([]pipelineKey, error) {
+	pipelineNames := []pipelineKey{}
 
 	if stages, exists := pipeline["stages"]; exists {
 
-		hasChildPipelines, err := hasValidChildPipelineStages(stages.([]interface{}), []string{pipeline["application"].(string)})
+		hasChildPipelines, err := hasValidChildPipelineStages(stages.([]interface{}))
 		if err != nil {
 			return pipelineNames, err
 		}
 
 		// If any of stages is of type pipeline collect those pipelines names recursively
 		if hasChildPipelines {
-			pipelineNames, err = s.getNestedPipelinesNames(stages, pipeline)
+			pipelineNames, err = s.getNestedPipelinesNames(stages)
 			if err != nil {
 				return pipelineNames, err
 			}
 		}
 	}
 
-	pipelineName := pipeline["name"].(string)
-	pipelineNames = append(pipelineNames, pipelineName)
+	pipelineNames = append(pipelineNames, getPipelineKey(pipeline))
 
-	deduplicatedPipelineNames := func(pipelineNames []string) []string {
-		keys := make(map[string]bool)
-		list := []string{}
+	return pipelineNames, nil
+}
 
-		for _, entry := range pipelineNames {
-			if _, value := keys[entry]; !value {
-				keys[entry] = true
-				list = append(list, entry)
-			}
-		}
-		return list
-	}(pipelineNames)
+// GetPipelinesNamesByApplication - gets the names of all the pipelines configured, grouped by application.
+//
+// Nested pipelines may belong to other applications than their parent, and the pipelines of a rendered set
+// may belong to different applications.
+// Applications are ordered by first appearance, children before their parents.
+func (s *SpinClient) GetPipelinesNamesByApplication(pipelineJSON string) ([]backend.ApplicationPipelines, error) {
+	pipelines, _, err := backend.ParsePipelines(pipelineJSON)
 
-	return deduplicatedPipelineNames, nil
-}
+	if err != nil {
+		return nil, err
+	}
 
-// GetPipelinesNamesAndApplication - gets list of names of all pipelines and application name configured
-func (s *SpinClient) GetPipelinesNamesAndApplication(pipelineJSON string) ([]string, string, error) {
+	var grouped []backend.ApplicationPipelines
+	applicationIndexes := make(map[string]int)
+	seenPipelines := make(map[pipelineKey]bool)
 
-	var pipeline map[string]interface{}
+	for _, pipeline := range pipelines {
+		if err := s.isValidPipeline(pipeline); err != nil {
+			return nil, err
+		}
 
-	err := jsoniter.Unmarshal([]byte(pipelineJSON), &pipeline)
+		keys, err := s.getPipelinesNames(pipeline)
 
-	if err != nil {
-		log.Fatal(err)
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	if err := s.isValidPipeline(pipeline); err != nil {
-		return []string{}, "", err
-	}
+		for _, key := range keys {
+			if seenPipelines[key] {
+				continue
+			}
 
-	application := pipeline["application"].(string)
+			seenPipelines[key] = true
+			index, exists := applicationIndexes[key.Application]
 
-	pipelineNames, err := s.getPipelinesNames(pipeline)
+			if !exists {
+				index = len(grouped)
+				applicationIndexes[key.Application] = index
+				grouped = append(grouped, backend.ApplicationPipelines{Application: key.Application})
+			}
 
-	if err != nil {
-		return []string{}, "", err
+			grouped[index].Names = append(grouped[index].Names, key.Name)
+		}
 	}
 
-	return pipelineNames, application, nil
+	return grouped, nil
 }
 
 // DeletePipeline - deletes rendered pipeline (recursively, if there are nested pipelines)
@@ -1003,33 +1233,41 @@ func (s *SpinClient) DeletePipeline(pipelineJSON string) (*http.Response, error)
 		return &http.Response{}, err
 	}
 
-	pipelineNames, application, err := s.GetPipelinesNamesAndApplication(pipelineJSON)
+	applications, err := s.GetPipelinesNamesByApplication(pipelineJSON)
 
 	if err != nil {
 		return &http.Response{}, err
 	}
-	// here and bellow %-40s hack is to replace possible spinner suffix interferring with the output
-	color.Yellow(fmt.Sprintf("\rApplication: %-40s ", application))
-	color.Yellow(fmt.Sprintf("Pipelines to delete: %s%-20s", pipelineNames, ""))
 
-	ch := make(chan DeletePipelineResponse, len(pipelineNames))
-	errCh := make(chan error)
+	var deleteErr error
+	deletions := []DeletePipelineResponse{}
 
-	go s.DeletePipelines(application, pipelineNames, ch, errCh)
-	err = <-errCh
+	for _, application := range applications {
+		// here and bellow %-40s hack is to replace possible spinner suffix interferring with the output
+		color.Yellow(fmt.Sprintf("\rApplication: %-40s ", application.Application))
+		color.Yellow(fmt.Sprintf("Pipelines to delete: %s%-20s", application.Names, ""))
 
-	deletions := []DeletePipelineResponse{}
+		ch := make(chan DeletePipelineResponse, len(application.Names))
+		errCh := make(chan error)
 
-	for deletion := range ch {
-		deletions = append(deletions, deletion)
+		go s.DeletePipelines(application.Application, application.Names, ch, errCh)
+
+		if err := <-errCh; err != nil {
+			deleteErr = multierror.Append(deleteErr, err)
+		}
+
+		for deletion := range ch {
+			deletions = append(deletions, deletion)
+		}
 	}
+
 	fmt.Printf("\r")
 	for _, d := range deletions {
 		color.Red(fmt.Sprintf("DELETED: %s - %-40s", d.App, d.Name))
 	}
 
-	if err != nil {
-		return &http.Response{StatusCode: http.StatusBadRequest}, err
+	if deleteErr != nil {
+		return &http.Response{StatusCode: http.StatusBadRequest}, deleteErr
 	}
 
 	return &http.Response{
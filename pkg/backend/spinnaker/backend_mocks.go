@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 	spinGateApi "github.com/spinnaker/spin/gateapi"
@@ -21,16 +22,123 @@ type MockPipelineControllerAPI struct {
 }
 type MockApplicationControllerAPI struct{}
 type MockApplicationControllerAPIWithEmptyID struct{}
+type MockPipelineConfigControllerAPI struct{}
+
+// driftPipeline - The pipelines of "drift-app", "Child" is managed by shore, "Parent" was changed by hand.
+func driftPipeline(pipelineName string) map[string]interface{} {
+	switch pipelineName {
+	case "Child":
+		return map[string]interface{}{
+			"application":    "drift-app",
+			"name":           "Child",
+			"id":             "drift-child",
+			"description":    "managed",
+			"lastModifiedBy": "alice",
+			"updateTs":       "1700000000000",
+		}
+	case "Parent":
+		return map[string]interface{}{
+			"application":    "drift-app",
+			"name":           "Parent",
+			"id":             "drift-parent",
+			"description":    "changed by hand",
+			"lastModifiedBy": "bob",
+			"updateTs":       "1700000100000",
+			"stages": []interface{}{
+				map[string]interface{}{"name": "Run Child", "type": "pipeline", "application": "drift-app", "pipeline": "drift-child"},
+			},
+		}
+	}
+
+	return map[string]interface{}{}
+}
 
 func (a *MockApplicationControllerAPI) GetPipelineConfigUsingGET(ctx context.Context, application string, pipelineName string) (map[string]interface{}, *http.Response, error) {
 	var res map[string]interface{}
 
 	if application == "not-exists" {
 		res = map[string]interface{}{}
+	} else if application == "missing-app" {
+		return nil, &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, fmt.Errorf("404 Not Found")
+	} else if application == "unavailable-app" {
+		return nil, &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("unavailable"))}, fmt.Errorf("503 Service Unavailable")
+	} else if application == "parameters-app" {
+		res = map[string]interface{}{
+			"name": pipelineName,
+			"id":   "4321",
+			"parameterConfig": []interface{}{
+				map[string]interface{}{"name": "region", "required": true, "hasOptions": true, "options": []interface{}{
+					map[string]interface{}{"value": "us-east-1"},
+					map[string]interface{}{"value": "eu-west-1"},
+				}},
+				map[string]interface{}{"name": "replicas", "default": "2"},
+				map[string]interface{}{"name": "dryRun", "default": ""},
+			},
+		}
+	} else if application == "drift-app" {
+		res = driftPipeline(pipelineName)
+	} else if application == "history-app" {
+		res = map[string]interface{}{
+			"name": pipelineName,
+			"id":   "history-" + pipelineName,
+		}
+	} else if application == "triggered-app" {
+		res = map[string]interface{}{
+			"name": pipelineName,
+			"id":   "5678",
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "jenkins", "master": "ci", "job": "build", "expectedArtifactIds": []interface{}{"artifact-id"}},
+				map[string]interface{}{"type": "webhook", "source": "my-webhook"},
+				map[string]interface{}{"type": "pipeline", "application": "triggered-app", "pipeline": "5678"},
+			},
+			"expectedArtifacts": []interface{}{
+				map[string]interface{}{"id": "artifact-id", "matchArtifact": map[string]interface{}{"type": "docker/image"}},
+				map[string]interface{}{"id": "other-artifact-id"},
+			},
+		}
+
+		if pipelineName == "test" {
+			res["parameterConfig"] = []interface{}{map[string]interface{}{"name": "answer"}}
+		}
+	} else if application == "unparameterized-app" {
+		res = map[string]interface{}{
+			"name": pipelineName,
+			"id":   "8765",
+		}
 	} else {
 		res = map[string]interface{}{
 			"name": pipelineName,
 			"id":   "1234",
+			"parameterConfig": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "answer"},
+			},
+		}
+	}
+
+	return res, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (a *MockApplicationControllerAPI) GetPipelineConfigsForApplicationUsingGET(ctx context.Context, application string) ([]interface{}, *http.Response, error) {
+	res := []interface{}{}
+
+	if application == "prune-app" {
+		owned := map[string]interface{}{"project": "test", "source": "main", "profile": "default"}
+
+		res = []interface{}{
+			map[string]interface{}{"application": application, "name": "Current Pipeline", "id": "1", "shore": owned},
+			map[string]interface{}{"application": application, "name": "Renamed Pipeline", "id": "2", "shore": owned},
+			map[string]interface{}{"application": application, "name": "Cleanup Pipeline", "id": "3", "shore": map[string]interface{}{"project": "test", "source": "cleanup", "profile": "default"}},
+			map[string]interface{}{"application": application, "name": "Other Project Pipeline", "id": "4", "shore": map[string]interface{}{"project": "other", "source": "main", "profile": "default"}},
+			map[string]interface{}{"application": application, "name": "Manual Pipeline", "id": "5"},
+			map[string]interface{}{"application": application, "name": "Other Profile Pipeline", "id": "6", "shore": map[string]interface{}{"project": "test", "source": "main", "profile": "prod"}},
+			map[string]interface{}{"application": application, "name": "Unnamed Project Pipeline", "id": "7", "shore": map[string]interface{}{"project": "test", "source": "main"}},
+		}
+	} else if application == "drift-app" {
+		res = []interface{}{driftPipeline("Child"), driftPipeline("Parent")}
+	} else if application == "triggered-app" {
+		res = []interface{}{
+			map[string]interface{}{"application": application, "name": "Triggered Pipeline", "id": "5678"},
 		}
 	}
 
@@ -65,6 +173,46 @@ func (p *MockPipelineControllerAPI) InvokePipelineConfigUsingPOST1(ctx context.C
 	return &http.Response{StatusCode: http.StatusOK}, nil
 }
 
+// GetPipelineConfigHistoryUsingGET - The pipelines of "history-app" have 3 revisions (newest first), saved by carol, bob & alice.
+// The revisions of the "Child" pipeline are saved 50 seconds before the revisions of the other pipelines, which run it.
+func (p *MockPipelineConfigControllerAPI) GetPipelineConfigHistoryUsingGET(ctx context.Context, pipelineConfigID string, localVarOptionals *spinGateApi.PipelineConfigControllerApiGetPipelineConfigHistoryUsingGETOpts) ([]interface{}, *http.Response, error) {
+	res := []interface{}{}
+	pipelineName := strings.TrimPrefix(pipelineConfigID, "history-")
+
+	if pipelineName == pipelineConfigID {
+		return res, &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	for i, user := range []string{"carol", "bob", "alice"} {
+		updateTs := 1700000000000 + int64(3-i)*100000
+
+		revision := map[string]interface{}{
+			"application":    "history-app",
+			"name":           pipelineName,
+			"id":             pipelineConfigID,
+			"description":    fmt.Sprintf("revision %d", i),
+			"lastModifiedBy": user,
+		}
+
+		if pipelineName == "Child" {
+			updateTs -= 50000
+		} else {
+			revision["stages"] = []interface{}{
+				map[string]interface{}{"name": "Run Child", "type": "pipeline", "application": "history-app", "pipeline": "history-Child"},
+			}
+		}
+
+		revision["updateTs"] = fmt.Sprint(updateTs)
+		res = append(res, revision)
+	}
+
+	if localVarOptionals != nil && localVarOptionals.Limit.IsSet() && int(localVarOptionals.Limit.Value()) < len(res) {
+		res = res[:localVarOptionals.Limit.Value()]
+	}
+
+	return res, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
 func (s *MockCustomSpinCli) ExecutePipeline(application, pipelineName string, args io.Reader) (*ExecutePipelineResponse, *http.Response, error) {
 	req, _ := http.NewRequest("POST", "url", args)
 	refID := -1
@@ -105,3 +253,7 @@ func (a *MockApplicationControllerAPIWithEmptyID) GetPipelineConfigUsingGET(ctx
 
 	return res, &http.Response{StatusCode: http.StatusOK}, nil
 }
+
+func (a *MockApplicationControllerAPIWithEmptyID) GetPipelineConfigsForApplicationUsingGET(ctx context.Context, application string) ([]interface{}, *http.Response, error) {
+	return []interface{}{}, &http.Response{StatusCode: http.StatusOK}, nil
+}
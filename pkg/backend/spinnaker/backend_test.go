@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Autodesk/shore/pkg/backend"
 	"github.com/Autodesk/shore/pkg/shore_testing"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/sirupsen/logrus"
@@ -25,9 +26,10 @@ func init() {
 		log:           logger,
 		CustomSpinCLI: &MockCustomSpinCli{},
 		SpinCLI: &SpinCLI{
-			ApplicationControllerAPI: &MockApplicationControllerAPI{},
-			PipelineControllerAPI:    &MockPipelineControllerAPI{},
-			Context:                  context.Background(),
+			ApplicationControllerAPI:    &MockApplicationControllerAPI{},
+			PipelineControllerAPI:       &MockPipelineControllerAPI{},
+			PipelineConfigControllerAPI: &MockPipelineConfigControllerAPI{},
+			Context:                     context.Background(),
 		},
 	}
 }
@@ -80,7 +82,7 @@ func TestInvalidFindAndReplacePipelineNameWithFoundID(t *testing.T) {
 			"pipeline":    "1234",
 		},
 	}
-	_, res := cli.findAndReplacePipelineNameWithFoundID(stageMap[0])
+	_, res, _ := cli.findAndReplacePipelineNameWithFoundID(stageMap[0], false)
 	assert.Equal(t, expectedResult[0], res)
 }
 
@@ -98,7 +100,7 @@ func TestValidFindAndReplacePipelineNameWithFoundIDInStage(t *testing.T) {
 		},
 	}
 
-	_, res := cli.findAndReplacePipelineNameWithFoundID(stageMap[0])
+	_, res, _ := cli.findAndReplacePipelineNameWithFoundID(stageMap[0], false)
 	assert.Equal(t, expectedResult[0], res)
 }
 
@@ -116,13 +118,13 @@ func TestValidFindAndReplacePipelineNameWithSpelInStage(t *testing.T) {
 		},
 	}
 
-	_, res := cli.findAndReplacePipelineNameWithFoundID(stageMap[0])
+	_, res, _ := cli.findAndReplacePipelineNameWithFoundID(stageMap[0], false)
 	assert.Equal(t, expectedResult[0], res)
 }
 
 func TestInternalSaveSuccessForExistingPipeline(t *testing.T) {
 	// Test
-	pipelineID, res, err := cli.savePipeline(`{"application": "test", "name": "test"}`)
+	pipelineID, res, err := cli.savePipeline(`{"application": "test", "name": "test"}`, nil)
 
 	// Assert
 	assert.Nil(t, err)
@@ -132,7 +134,7 @@ func TestInternalSaveSuccessForExistingPipeline(t *testing.T) {
 
 func TestInternalSaveSuccessForNonExistingPipeline(t *testing.T) {
 	// Test
-	pipelineID, res, err := cli.savePipeline(`{"application": "not-exists", "name": "test"}`)
+	pipelineID, res, err := cli.savePipeline(`{"application": "not-exists", "name": "test"}`, nil)
 
 	// Assert
 	assert.Nil(t, err)
@@ -142,7 +144,7 @@ func TestInternalSaveSuccessForNonExistingPipeline(t *testing.T) {
 
 func TestInternalSaveFailedApplication(t *testing.T) {
 	// Test
-	_, _, err := cli.savePipeline(`{"name": "test"}`)
+	_, _, err := cli.savePipeline(`{"name": "test"}`, nil)
 
 	// Assert
 	assert.EqualError(t, err, "required pipeline key 'application' missing")
@@ -151,7 +153,7 @@ func TestInternalSaveFailedApplication(t *testing.T) {
 func TestInternalSaveFailedName(t *testing.T) {
 
 	// Test
-	_, _, err := cli.savePipeline(`{"application": "test"}`)
+	_, _, err := cli.savePipeline(`{"application": "test"}`, nil)
 
 	// Assert
 	assert.EqualError(t, err, "required pipeline key 'name' missing")
@@ -362,7 +364,7 @@ func TestSaveSuccess(t *testing.T) {
 	`
 
 	// Test
-	res, err := cli.SavePipeline(nestedPipelineString)
+	res, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.Nil(t, err)
@@ -386,7 +388,7 @@ func TestSaveSimplePipelineSuccess(t *testing.T) {
 	`
 
 	// Test
-	res, err := cli.SavePipeline(nestedPipelineString)
+	res, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.Nil(t, err)
@@ -420,7 +422,7 @@ func TestMissingApplicationFailedSave(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(nestedPipelineString)
+	_, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.EqualError(t, err, "required pipeline key 'application' missing")
@@ -453,12 +455,46 @@ func TestMissingNameFailedSave(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(nestedPipelineString)
+	_, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.EqualError(t, err, "required pipeline key 'name' missing")
 }
 
+func TestPipelineCrossApplicationChildPipelineSave(t *testing.T) {
+	// Given
+	nestedPipelineString := `
+	{
+		"application": "appname",
+		"name":  "Nested pipeline",
+		"stages": [
+			{
+				"application": "another appname",
+				"name":  "Nested pipeline stage",
+				"type": "pipeline",
+				"pipeline": {
+					"application": "another appname",
+					"name": "Child pipeline 1",
+					"stages": [
+						{
+							"name": "child pipeline 1 stage",
+							"type": "wait"
+						}
+					]
+				}
+			}
+		]
+	}
+	`
+
+	// Test
+	res, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
 func TestPipelineChildPipelineWrongApplicationFailedSave(t *testing.T) {
 	// Given
 	nestedPipelineString := `
@@ -486,10 +522,10 @@ func TestPipelineChildPipelineWrongApplicationFailedSave(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(nestedPipelineString)
+	_, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
-	assert.EqualError(t, err, "pipeline 'application' key value should match the value of parent pipeline 'application' key")
+	assert.EqualError(t, err, "'application' key value of stage of type 'pipeline' should match the 'application' key value of its child pipeline")
 }
 
 func TestPipelineStageMissingApplicationFailedSave(t *testing.T) {
@@ -518,7 +554,7 @@ func TestPipelineStageMissingApplicationFailedSave(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(nestedPipelineString)
+	_, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.EqualError(t, err, "required stage key 'application' missing for stage")
@@ -551,10 +587,10 @@ func TestPipelineStageWrongApplicationFailedSave(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(nestedPipelineString)
+	_, err := cli.SavePipeline(nestedPipelineString, backend.SaveOptions{})
 
 	// Assert
-	assert.EqualError(t, err, "'application' key value of stage of type 'pipeline' should match the one of parent pipeline 'application' value")
+	assert.EqualError(t, err, "'application' key value of stage of type 'pipeline' should match the 'application' key value of its child pipeline")
 }
 
 func TestPipelineSaveEmptyTriggersAndStages(t *testing.T) {
@@ -569,7 +605,7 @@ func TestPipelineSaveEmptyTriggersAndStages(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -600,7 +636,7 @@ func TestPipelineSaveTriggersAndStagesWithValidPipelineID(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -676,7 +712,7 @@ func TestPipelineSaveTriggersAndStagesWithValidPipelineIDPlusNested(t *testing.T
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -752,7 +788,7 @@ func TestPipelineSaveTriggersAndStagesAndLookupPipelineIDPlusNested(t *testing.T
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -783,7 +819,7 @@ func TestPipelineSaveTriggersAndStagesAndLookupPipelineID(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -841,7 +877,7 @@ func TestPipelineSaveWithNestedLookUp(t *testing.T) {
 	`
 
 	// Test
-	res, saveErr := cli.SavePipeline(pipelineString)
+	res, saveErr := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 	defer res.Body.Close()
 
 	body, bodyErr := ioutil.ReadAll(res.Body)
@@ -880,7 +916,7 @@ func TestPipelineSaveTriggersAndStagesWithReplacablePipelineID(t *testing.T) {
 	`
 
 	// Test
-	_, err := cli.SavePipeline(pipelineString)
+	_, err := cli.SavePipeline(pipelineString, backend.SaveOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -900,7 +936,7 @@ func TestTestingRemoteSuccess(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -929,7 +965,7 @@ func TestTestingRemoteNoTestsFound(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -960,7 +996,7 @@ func TestTestingRemoteNoAssertionFailed(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "1234",
 						},
@@ -975,6 +1011,23 @@ func TestTestingRemoteNoAssertionFailed(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTestingRemoteParametersNotAnObject(t *testing.T) {
+	config := shore_testing.TestsConfig{
+		Application: "test1test2test3",
+		Pipeline:    "abc",
+		Tests: map[string]shore_testing.TestConfig{
+			"test success": {
+				ExecArgs:   map[string]interface{}{"parameters": []interface{}{"a"}},
+				Assertions: map[string]shore_testing.Assertion{},
+			},
+		},
+	}
+
+	err := cli.TestPipeline(config, func() {}, true)
+
+	assert.EqualError(t, err, "`test success`: `parameters` must be an object")
+}
+
 func TestTestingRemoteNoAssertionForStageError(t *testing.T) {
 	config := shore_testing.TestsConfig{
 		Application: "test1test2test3",
@@ -1000,7 +1053,7 @@ func TestTestingRemoteMissingExecArgs(t *testing.T) {
 			"test success": {
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1022,7 +1075,7 @@ func TestTestingNoApplicationFailed(t *testing.T) {
 			"test success": {
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1044,7 +1097,7 @@ func TestTestingNoPipelineFailed(t *testing.T) {
 			"test success": {
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1073,7 +1126,7 @@ func TestTestingBadTimeout(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1102,7 +1155,7 @@ func TestTestingTimeout(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1132,7 +1185,7 @@ func TestTestingRemoteStringifyTrue(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1162,7 +1215,7 @@ func TestTestingRemoteStringifyFalse(t *testing.T) {
 				},
 				Assertions: map[string]shore_testing.Assertion{
 					"testedname": {
-						ExpectedStatus: "succeeded",
+						ExpectedStatus: shore_testing.NewStatusMatcher("succeeded"),
 						ExpectedOutput: map[string]interface{}{
 							"test": "123",
 						},
@@ -1359,7 +1412,7 @@ func TestPipelineChildPipelineWrongApplicationFailedDelete(t *testing.T) {
 	_, err := cli.DeletePipeline(nestedPipelineString)
 
 	// Assert
-	assert.EqualError(t, err, "pipeline 'application' key value should match the value of parent pipeline 'application' key")
+	assert.EqualError(t, err, "'application' key value of stage of type 'pipeline' should match the 'application' key value of its child pipeline")
 }
 
 func TestDeleteDryRunSuccess(t *testing.T) {
@@ -1477,7 +1530,11 @@ func TestGetNestedPipelinesNames(t *testing.T) {
 		]
 	}
 	`
-	expectedPipelineNames := []string{"Child pipeline 2", "Child pipeline 2.2", "Child pipeline 1"}
+	expectedPipelineNames := []pipelineKey{
+		{Application: "appname", Name: "Child pipeline 2"},
+		{Application: "appname", Name: "Child pipeline 2.2"},
+		{Application: "appname", Name: "Child pipeline 1"},
+	}
 
 	var pipeline map[string]interface{}
 
@@ -1485,7 +1542,7 @@ func TestGetNestedPipelinesNames(t *testing.T) {
 
 	stages := pipeline["stages"]
 	// Test
-	pipelineNames, err2 := cli.getNestedPipelinesNames(stages, pipeline)
+	pipelineNames, err2 := cli.getNestedPipelinesNames(stages)
 
 	// Assert
 	assert.Nil(t, err)
@@ -1546,7 +1603,12 @@ func TestGetPipelinesNames(t *testing.T) {
 		]
 	}
 	`
-	expectedPipelineNames := []string{"Child pipeline 2", "Child pipeline 2.2", "Child pipeline 1", "Nested pipeline"}
+	expectedPipelineNames := []pipelineKey{
+		{Application: "appname", Name: "Child pipeline 2"},
+		{Application: "appname", Name: "Child pipeline 2.2"},
+		{Application: "appname", Name: "Child pipeline 1"},
+		{Application: "appname", Name: "Nested pipeline"},
+	}
 
 	var pipeline map[string]interface{}
 
@@ -1561,7 +1623,7 @@ func TestGetPipelinesNames(t *testing.T) {
 	assert.Equal(t, expectedPipelineNames, pipelineNames)
 }
 
-func TestGetPipelinesNamesAndApplication(t *testing.T) {
+func TestGetPipelinesNamesByApplication(t *testing.T) {
 	nestedPipelineString := `
 	{
 		"application": "appname",
@@ -1618,10 +1680,271 @@ func TestGetPipelinesNamesAndApplication(t *testing.T) {
 	expectedApplication := "appname"
 
 	// Test
-	pipelineNames, application, err := cli.GetPipelinesNamesAndApplication(nestedPipelineString)
+	applications, err := cli.GetPipelinesNamesByApplication(nestedPipelineString)
 
 	// Assert
 	assert.Nil(t, err)
-	assert.Equal(t, expectedPipelineNames, pipelineNames)
-	assert.Equal(t, expectedApplication, application)
+	assert.Len(t, applications, 1)
+	assert.Equal(t, expectedPipelineNames, applications[0].Names)
+	assert.Equal(t, expectedApplication, applications[0].Application)
+}
+
+func TestSavePipelineSetSuccess(t *testing.T) {
+	// Given
+	pipelinesString := `
+	[
+		{
+			"application": "appname",
+			"name": "us-east-1",
+			"stages": [{"name": "Wait", "type": "wait", "waitTime": 1}]
+		},
+		{
+			"application": "appname",
+			"name": "eu-west-1",
+			"stages": [{"name": "Wait", "type": "wait", "waitTime": 1}]
+		}
+	]
+	`
+
+	// Test
+	res, err := cli.SavePipeline(pipelinesString, backend.SaveOptions{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestSavePipelineSetMissingNameFails(t *testing.T) {
+	// Given
+	pipelinesString := `{"pipelines": [{"application": "appname", "name": "us-east-1"}, {"application": "appname"}]}`
+
+	// Test
+	_, err := cli.SavePipeline(pipelinesString, backend.SaveOptions{})
+
+	// Assert
+	assert.EqualError(t, err, "required pipeline key 'name' missing")
+}
+
+func TestSortPipelinesByDependencies(t *testing.T) {
+	// Given
+	pipelines := []map[string]interface{}{
+		{
+			"application": "appname",
+			"name":        "deploy",
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "pipeline", "application": "appname", "pipeline": "build"},
+			},
+		},
+		{
+			"application": "appname",
+			"name":        "build",
+		},
+		{
+			"application": "appname",
+			"name":        "verify",
+			"stages": []interface{}{
+				map[string]interface{}{"name": "Deploy", "type": "pipeline", "application": "appname", "pipeline": "deploy"},
+			},
+		},
+	}
+
+	// Test
+	sorted, err := sortPipelinesByDependencies(pipelines)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "build", sorted[0]["name"])
+	assert.Equal(t, "deploy", sorted[1]["name"])
+	assert.Equal(t, "verify", sorted[2]["name"])
+}
+
+func TestSortPipelinesByDependenciesNestedPipeline(t *testing.T) {
+	// Given
+	pipelines := []map[string]interface{}{
+		{
+			"application": "appname",
+			"name":        "deploy",
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "pipeline", "application": "appname", "pipeline": "build"},
+			},
+		},
+		{
+			"application": "appname",
+			"name":        "release",
+			"stages": []interface{}{
+				map[string]interface{}{"name": "Build", "type": "pipeline", "application": "appname", "pipeline": map[string]interface{}{
+					"application": "appname",
+					"name":        "build",
+				}},
+			},
+		},
+	}
+
+	// Test
+	sorted, err := sortPipelinesByDependencies(pipelines)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "release", sorted[0]["name"])
+	assert.Equal(t, "deploy", sorted[1]["name"])
+}
+
+func TestSortPipelinesByDependenciesCycleFails(t *testing.T) {
+	// Given
+	pipelines := []map[string]interface{}{
+		{
+			"application": "appname",
+			"name":        "first",
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "pipeline", "application": "appname", "pipeline": "second"},
+			},
+		},
+		{
+			"application": "appname",
+			"name":        "second",
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "pipeline", "application": "appname", "pipeline": "first"},
+			},
+		},
+	}
+
+	// Test
+	_, err := sortPipelinesByDependencies(pipelines)
+
+	// Assert
+	assert.EqualError(t, err, "pipelines in the set reference each other in a cycle: appname/first -> appname/second -> appname/first")
+}
+
+func TestSortPipelinesByDependenciesDuplicateFails(t *testing.T) {
+	// Given
+	pipelines := []map[string]interface{}{
+		{"application": "appname", "name": "first"},
+		{"application": "appname", "name": "first"},
+	}
+
+	// Test
+	_, err := sortPipelinesByDependencies(pipelines)
+
+	// Assert
+	assert.EqualError(t, err, `pipeline "appname/first" is rendered more than once in the set`)
+}
+
+func TestGetPipelinesNamesByApplicationForSet(t *testing.T) {
+	// Given
+	pipelinesString := `
+	[
+		{
+			"application": "appname",
+			"name": "us-east-1",
+			"stages": [
+				{
+					"application": "appname",
+					"name": "Nested pipeline stage",
+					"type": "pipeline",
+					"pipeline": {"application": "appname", "name": "us-east-1 child"}
+				}
+			]
+		},
+		{"application": "appname", "name": "eu-west-1"}
+	]
+	`
+
+	// Test
+	applications, err := cli.GetPipelinesNamesByApplication(pipelinesString)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []backend.ApplicationPipelines{
+		{Application: "appname", Names: []string{"us-east-1 child", "us-east-1", "eu-west-1"}},
+	}, applications)
+}
+
+func TestGetPipelinesNamesByApplicationForSetDifferentApplications(t *testing.T) {
+	// Given
+	pipelinesString := `[{"application": "appname", "name": "us-east-1"}, {"application": "other", "name": "eu-west-1"}]`
+
+	// Test
+	applications, err := cli.GetPipelinesNamesByApplication(pipelinesString)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []backend.ApplicationPipelines{
+		{Application: "appname", Names: []string{"us-east-1"}},
+		{Application: "other", Names: []string{"eu-west-1"}},
+	}, applications)
+}
+
+func TestGetPipelinesNamesByApplicationCrossApplicationChildren(t *testing.T) {
+	// Given
+	nestedPipelineString := `
+	{
+		"application": "platform",
+		"name": "Orchestrator",
+		"stages": [
+			{
+				"application": "team-a",
+				"name": "Deploy team A",
+				"type": "pipeline",
+				"pipeline": {"application": "team-a", "name": "Deploy"}
+			},
+			{
+				"application": "team-b",
+				"name": "Deploy team B",
+				"type": "pipeline",
+				"pipeline": {"application": "team-b", "name": "Deploy"}
+			},
+			{
+				"application": "platform",
+				"name": "Verify",
+				"type": "pipeline",
+				"pipeline": {"application": "platform", "name": "Verify"}
+			}
+		]
+	}
+	`
+
+	// Test
+	applications, err := cli.GetPipelinesNamesByApplication(nestedPipelineString)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []backend.ApplicationPipelines{
+		{Application: "team-a", Names: []string{"Deploy"}},
+		{Application: "team-b", Names: []string{"Deploy"}},
+		{Application: "platform", Names: []string{"Verify", "Orchestrator"}},
+	}, applications)
+}
+
+func TestGetPipelineHistory(t *testing.T) {
+	// Test
+	revisions, err := cli.GetPipelineHistory("history-app", "Parent", 2)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "carol", revisions[0]["lastModifiedBy"])
+	assert.Equal(t, "bob", revisions[1]["lastModifiedBy"])
+}
+
+func TestGetPipelineHistoryPipelineNotExists(t *testing.T) {
+	// Test
+	revisions, err := cli.GetPipelineHistory("not-exists", "Parent", 0)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestTestingPipelineUnavailable(t *testing.T) {
+	config := shore_testing.TestsConfig{
+		Application: "unavailable-app",
+		Pipeline:    "test",
+		Tests: map[string]shore_testing.TestConfig{
+			"test success": {},
+		},
+	}
+
+	err := cli.TestPipeline(config, func() {}, true)
+
+	assert.EqualError(t, err, `response code 503: "unavailable"`)
 }
@@ -0,0 +1,125 @@
+package spinnaker
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// validateParameters - Validates the `parameters` of the execution arguments against the `parameterConfig` of
+// the pipeline configuration and applies the defaults of the missing parameters.
+//
+// Pipelines that don't exist (I.E. not saved yet) aren't validated, a pipeline without a `parameterConfig` declares no parameters.
+func validateParameters(pipelineName string, pipeline map[string]interface{}, args map[string]interface{}) error {
+	if len(pipeline) == 0 {
+		return nil
+	}
+
+	parameterConfig, _ := pipeline["parameterConfig"].([]interface{})
+
+	parameters, _ := args["parameters"].(map[string]interface{})
+
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+
+	declared := make(map[string]bool)
+	var errorsList []string
+
+	for _, config := range parameterConfig {
+		parameter, ok := config.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		name, _ := parameter["name"].(string)
+		declared[name] = true
+		value, hasValue := parameters[name]
+
+		if !hasValue || value == "" {
+			if defaultValue := parameter["default"]; defaultValue != nil && defaultValue != "" {
+				parameters[name] = defaultValue
+				continue
+			}
+
+			if required, _ := parameter["required"].(bool); required {
+				errorsList = append(errorsList, fmt.Sprintf("missing required parameter %q", name))
+			}
+
+			continue
+		}
+
+		if hasOptions, _ := parameter["hasOptions"].(bool); hasOptions {
+			options := parameterOptions(parameter)
+
+			if !containsString(options, fmt.Sprint(value)) {
+				errorsList = append(errorsList, fmt.Sprintf("parameter %q value %q isn't one of the options: %s", name, fmt.Sprint(value), strings.Join(options, ", ")))
+			}
+		}
+	}
+
+	for name := range parameters {
+		if !declared[name] {
+			errorsList = append(errorsList, fmt.Sprintf("unknown parameter %q", name))
+		}
+	}
+
+	if len(errorsList) > 0 {
+		sort.Strings(errorsList)
+		return fmt.Errorf("invalid parameters for pipeline %q:\n\t%s", pipelineName, strings.Join(errorsList, "\n\t"))
+	}
+
+	if len(parameters) > 0 {
+		args["parameters"] = parameters
+	}
+
+	return nil
+}
+
+// parameterOptions - The values of the `options` of a parameter.
+func parameterOptions(parameter map[string]interface{}) []string {
+	rawOptions, _ := parameter["options"].([]interface{})
+	options := make([]string, 0, len(rawOptions))
+
+	for _, rawOption := range rawOptions {
+		if option, ok := rawOption.(map[string]interface{}); ok {
+			options = append(options, fmt.Sprint(option["value"]))
+		}
+	}
+
+	return options
+}
+
+// parametersObject - A copy of the `parameters` of the execution arguments, which must be an object (or unset).
+func parametersObject(parameters interface{}) (map[string]interface{}, error) {
+	if parameters == nil {
+		return make(map[string]interface{}), nil
+	}
+
+	value := reflect.ValueOf(parameters)
+
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("`parameters` must be an object")
+	}
+
+	copied := make(map[string]interface{}, value.Len())
+	iterator := value.MapRange()
+
+	for iterator.Next() {
+		copied[iterator.Key().String()] = iterator.Value().Interface()
+	}
+
+	return copied, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package spinnaker
+
+import (
+	"io/ioutil"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecParametersDefaults(t *testing.T) {
+	// Test
+	_, res, err := cli.ExecutePipeline(`{"application": "parameters-app", "pipeline": "test", "parameters": {"region": "eu-west-1"}}`, true)
+	bodyString, _ := ioutil.ReadAll(res.Request.Body)
+	var body map[string]interface{}
+	jsoniter.Unmarshal(bodyString, &body)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"region": "eu-west-1", "replicas": "2"}, body["parameters"])
+}
+
+func TestExecParametersInvalid(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "parameters-app", "pipeline": "test", "parameters": {"regoin": "eu-west-1", "replicas": 3}}`, true)
+
+	// Assert
+	assert.EqualError(t, err, "invalid parameters for pipeline \"test\":\n\tmissing required parameter \"region\"\n\tunknown parameter \"regoin\"")
+}
+
+func TestExecParametersNotAnOption(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "parameters-app", "pipeline": "test", "parameters": {"region": "ap-south-1"}}`, true)
+
+	// Assert
+	assert.EqualError(t, err, "invalid parameters for pipeline \"test\":\n\tparameter \"region\" value \"ap-south-1\" isn't one of the options: us-east-1, eu-west-1")
+}
+
+func TestExecParametersMissing(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "parameters-app", "pipeline": "test"}`, true)
+
+	// Assert
+	assert.EqualError(t, err, "invalid parameters for pipeline \"test\":\n\tmissing required parameter \"region\"")
+}
+
+func TestExecParametersWithoutParameterConfig(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "unparameterized-app", "pipeline": "test", "parameters": {"anything": "goes"}}`, true)
+
+	// Assert
+	assert.EqualError(t, err, "invalid parameters for pipeline \"test\":\n\tunknown parameter \"anything\"")
+}
+
+func TestExecParametersPipelineNotExists(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "not-exists", "pipeline": "test", "parameters": {"anything": "goes"}}`, true)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestExecParametersPipelineNotFound(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "missing-app", "pipeline": "test", "parameters": {"anything": "goes"}}`, true)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestExecParametersPipelineUnavailable(t *testing.T) {
+	// Test
+	_, _, err := cli.ExecutePipeline(`{"application": "unavailable-app", "pipeline": "test"}`, true)
+
+	// Assert
+	var controllerErr *ApplicationControllerError
+	assert.ErrorAs(t, err, &controllerErr)
+	assert.EqualError(t, err, `response code 503: "unavailable"`)
+}
@@ -0,0 +1,251 @@
+package spinnaker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// pipelineKey - uniquely identifies a pipeline in Spinnaker.
+type pipelineKey struct {
+	Application string
+	Name        string
+}
+
+func (k pipelineKey) String() string {
+	return fmt.Sprintf("%s/%s", k.Application, k.Name)
+}
+
+// getPipelineKey - returns the key of a pipeline object, assumes the pipeline was validated with `isValidPipeline`.
+func getPipelineKey(pipeline map[string]interface{}) pipelineKey {
+	application, _ := pipeline["application"].(string)
+	name, _ := pipeline["name"].(string)
+
+	return pipelineKey{Application: application, Name: name}
+}
+
+// getPipelineReferences - Collects the pipelines referenced by name from the triggers and stages of a pipeline.
+// Nested pipeline objects (`NestedPipelineStage`) are walked recursively.
+// References that are already a pipeline UUID or a SpEL expression are skipped.
+func getPipelineReferences(pipeline map[string]interface{}) []pipelineKey {
+	var references []pipelineKey
+
+	collect := func(objects interface{}) {
+		objectsSlice, ok := objects.([]interface{})
+		if !ok {
+			return
+		}
+
+		for _, object := range objectsSlice {
+			objectMap, ok := object.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			application, appIsString := objectMap["application"].(string)
+
+			switch referenced := objectMap["pipeline"].(type) {
+			case string:
+				if !appIsString {
+					continue
+				}
+
+				if isUUID, _ := isValidv4UUIDtypeRFC4122(referenced); isUUID || isSpEL(referenced) {
+					continue
+				}
+
+				references = append(references, pipelineKey{Application: application, Name: referenced})
+			case map[string]interface{}:
+				references = append(references, getPipelineReferences(referenced)...)
+			}
+		}
+	}
+
+	collect(pipeline["triggers"])
+	collect(pipeline["stages"])
+
+	return references
+}
+
+// sortPipelinesByDependencies - Orders a set of pipelines so that pipelines referenced by name
+// (I.E. a pipeline trigger or a pipeline stage) are saved before the pipelines referencing them.
+// The original order is kept between pipelines that do not depend on each other.
+//
+// Nested pipeline objects (`NestedPipelineStage`) are saved with the pipeline containing them,
+// a reference to a nested pipeline depends on the top level pipeline containing it.
+func sortPipelinesByDependencies(pipelines []map[string]interface{}) ([]map[string]interface{}, error) {
+	indexes := make(map[pipelineKey]int, len(pipelines))
+
+	for i, pipeline := range pipelines {
+		key := getPipelineKey(pipeline)
+
+		if _, exists := indexes[key]; exists {
+			return nil, fmt.Errorf("pipeline %q is rendered more than once in the set", key)
+		}
+
+		indexes[key] = i
+	}
+
+	for i, pipeline := range pipelines {
+		for _, key := range getRenderedPipelineKeys(pipeline)[1:] {
+			if _, exists := indexes[key]; !exists {
+				indexes[key] = i
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(pipelines))
+	sorted := make([]map[string]interface{}, 0, len(pipelines))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("pipelines in the set reference each other in a cycle: %s", strings.Join(append(path, getPipelineKey(pipelines[i]).String()), " -> "))
+		}
+
+		state[i] = visiting
+		path = append(path, getPipelineKey(pipelines[i]).String())
+
+		for _, reference := range getPipelineReferences(pipelines[i]) {
+			dependency, inSet := indexes[reference]
+
+			if !inSet || dependency == i {
+				continue
+			}
+
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[i] = visited
+		sorted = append(sorted, pipelines[i])
+
+		return nil
+	}
+
+	for i := range pipelines {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// getRenderedPipelineKeys - returns the keys of a pipeline and of its nested pipeline objects (`NestedPipelineStage`).
+func getRenderedPipelineKeys(pipeline map[string]interface{}) []pipelineKey {
+	keys := []pipelineKey{getPipelineKey(pipeline)}
+	stages, _ := pipeline["stages"].([]interface{})
+
+	for _, stage := range stages {
+		stageMap, _ := stage.(map[string]interface{})
+
+		if childPipeline, ok := stageMap["pipeline"].(map[string]interface{}); ok {
+			keys = append(keys, getRenderedPipelineKeys(childPipeline)...)
+		}
+	}
+
+	return keys
+}
+
+// resolveMissingReferences - The first phase of saving a rendered output, finds the pipelines referenced by name
+// that aren't rendered and don't exist yet, before anything is saved.
+//
+// Missing pipelines are created as empty placeholders (`CreateMissing`), left unbound (`AllowUnbound`) or reported.
+func (s *SpinClient) resolveMissingReferences(pipelines []map[string]interface{}, options backend.SaveOptions) error {
+	rendered := make(map[pipelineKey]bool)
+
+	for _, pipeline := range pipelines {
+		for _, key := range getRenderedPipelineKeys(pipeline) {
+			rendered[key] = true
+		}
+	}
+
+	var missing []pipelineKey
+	checked := make(map[pipelineKey]bool)
+
+	for _, pipeline := range pipelines {
+		for _, reference := range getPipelineReferences(pipeline) {
+			if rendered[reference] || checked[reference] {
+				continue
+			}
+
+			checked[reference] = true
+			id, _, err := s.getOtherPipelineId(reference.Application, reference.Name)
+
+			if err != nil && !isNotFoundError(err) {
+				return err
+			}
+
+			if id == "" {
+				missing = append(missing, reference)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	missingNames := make([]string, 0, len(missing))
+
+	for _, key := range missing {
+		missingNames = append(missingNames, key.String())
+	}
+
+	switch {
+	case options.CreateMissing:
+		for _, key := range missing {
+			s.log.Warnf("Creating placeholder pipeline %q", key)
+
+			if err := s.createPlaceholderPipeline(key); err != nil {
+				return err
+			}
+		}
+	case options.AllowUnbound:
+		s.log.Warnf("Referenced pipelines don't exist, their references are unbound: %s", strings.Join(missingNames, ", "))
+	default:
+		return fmt.Errorf("referenced pipelines don't exist: %s\nuse `--create-missing` to create placeholder pipelines or `--allow-unbound` to save unbound references", strings.Join(missingNames, ", "))
+	}
+
+	return nil
+}
+
+// createPlaceholderPipeline - Creates an empty pipeline, so references to it can be resolved to an ID.
+//
+// Placeholders aren't rendered by the project, they aren't stamped with its ownership (and aren't pruned).
+func (s *SpinClient) createPlaceholderPipeline(key pipelineKey) error {
+	placeholder, err := jsoniter.Marshal(map[string]interface{}{
+		"application": key.Application,
+		"name":        key.Name,
+		"description": "Placeholder pipeline created by shore, referenced by another pipeline.",
+		"stages":      []interface{}{},
+		"triggers":    []interface{}{},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := s.savePipeline(string(placeholder), nil); err != nil {
+		return err
+	}
+
+	_, _, err = s.pollSpinnakerGetPipelineConfigUsingGET(key.Application, key.Name)
+
+	return err
+}
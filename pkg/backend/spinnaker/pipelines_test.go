@@ -0,0 +1,269 @@
+package spinnaker
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	jsoniter "github.com/json-iterator/go"
+	spinGateApi "github.com/spinnaker/spin/gateapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubSpinnaker - An in memory Spinnaker, pipelines are found once saved.
+type stubSpinnaker struct {
+	pipelines map[pipelineKey]map[string]interface{}
+}
+
+func (s *stubSpinnaker) GetPipelineConfigUsingGET(ctx context.Context, application string, pipelineName string) (map[string]interface{}, *http.Response, error) {
+	pipeline, exists := s.pipelines[pipelineKey{Application: application, Name: pipelineName}]
+
+	if !exists {
+		pipeline = map[string]interface{}{}
+	}
+
+	return pipeline, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (s *stubSpinnaker) GetPipelineConfigsForApplicationUsingGET(ctx context.Context, application string) ([]interface{}, *http.Response, error) {
+	var names []string
+
+	for key := range s.pipelines {
+		if key.Application == application {
+			names = append(names, key.Name)
+		}
+	}
+
+	sort.Strings(names)
+	pipelines := make([]interface{}, 0, len(names))
+
+	for _, name := range names {
+		pipelines = append(pipelines, s.pipelines[pipelineKey{Application: application, Name: name}])
+	}
+
+	return pipelines, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (s *stubSpinnaker) SavePipelineUsingPOST(ctx context.Context, pipeline interface{}, localVarOptionals *spinGateApi.PipelineControllerApiSavePipelineUsingPOSTOpts) (*http.Response, error) {
+	pipelineMap := pipeline.(map[string]interface{})
+	key := getPipelineKey(pipelineMap)
+
+	if _, exists := pipelineMap["id"]; !exists {
+		pipelineMap["id"] = "id-" + key.Name
+	}
+
+	s.pipelines[key] = pipelineMap
+
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (s *stubSpinnaker) DeletePipelineUsingDELETE(ctx context.Context, application string, pipeline string) (*http.Response, error) {
+	delete(s.pipelines, pipelineKey{Application: application, Name: pipeline})
+
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func newStubClient(stub *stubSpinnaker) *SpinClient {
+	client := &SpinClient{
+		log:           logger,
+		CustomSpinCLI: &MockCustomSpinCli{},
+		SpinCLI: &SpinCLI{
+			ApplicationControllerAPI: stub,
+			PipelineControllerAPI:    stub,
+			Context:                  context.Background(),
+		},
+	}
+
+	client.initOnce.Do(func() {})
+
+	return client
+}
+
+const referencingPipeline = `
+{
+	"application": "appname",
+	"name": "deploy",
+	"triggers": [{"type": "pipeline", "application": "other", "pipeline": "build"}],
+	"stages": [{"name": "Verify", "type": "pipeline", "application": "other", "pipeline": "verify"}]
+}
+`
+
+func TestSavePipelineMissingReferencesFails(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+
+	// Test
+	_, err := client.SavePipeline(referencingPipeline, backend.SaveOptions{})
+
+	// Assert
+	assert.EqualError(t, err, "referenced pipelines don't exist: other/build, other/verify\nuse `--create-missing` to create placeholder pipelines or `--allow-unbound` to save unbound references")
+	assert.Empty(t, stub.pipelines)
+}
+
+func TestSavePipelineCreateMissingReferences(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{
+		{Application: "other", Name: "verify"}: {"application": "other", "name": "verify", "id": "id-verify"},
+	}}
+	client := newStubClient(stub)
+
+	// Test
+	_, err := client.SavePipeline(referencingPipeline, backend.SaveOptions{CreateMissing: true})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, stub.pipelines, pipelineKey{Application: "other", Name: "build"})
+
+	saved := stub.pipelines[pipelineKey{Application: "appname", Name: "deploy"}]
+	assert.Equal(t, "id-build", saved["triggers"].([]interface{})[0].(map[string]interface{})["pipeline"])
+	assert.Equal(t, "id-verify", saved["stages"].([]interface{})[0].(map[string]interface{})["pipeline"])
+}
+
+func TestSavePipelineAllowUnboundReferences(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+
+	// Test
+	_, err := client.SavePipeline(referencingPipeline, backend.SaveOptions{AllowUnbound: true})
+
+	// Assert
+	assert.Nil(t, err)
+
+	saved := stub.pipelines[pipelineKey{Application: "appname", Name: "deploy"}]
+	assert.Nil(t, saved["triggers"].([]interface{})[0].(map[string]interface{})["pipeline"])
+	assert.Len(t, stub.pipelines, 1)
+}
+
+func TestSavePipelineSetForwardReferences(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+	pipelinesString := `[
+		{"application": "appname", "name": "deploy", "triggers": [{"type": "pipeline", "application": "appname", "pipeline": "build"}]},
+		{"application": "appname", "name": "build"}
+	]`
+
+	// Test
+	_, err := client.SavePipeline(pipelinesString, backend.SaveOptions{})
+
+	// Assert
+	assert.Nil(t, err)
+
+	saved := stub.pipelines[pipelineKey{Application: "appname", Name: "deploy"}]
+	assert.Equal(t, "id-build", saved["triggers"].([]interface{})[0].(map[string]interface{})["pipeline"])
+}
+
+func TestSavePipelineAllowUnboundStageReference(t *testing.T) {
+	// Test
+	res, err := cli.SavePipeline(`{"application": "appname", "name": "deploy", "stages": [{"name": "Run", "type": "pipeline", "application": "not-exists", "pipeline": "child"}]}`, backend.SaveOptions{AllowUnbound: true})
+	body, _ := ioutil.ReadAll(res.Body)
+	var saved map[string]interface{}
+	jsoniter.Unmarshal(body, &saved)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, saved["stages"].([]interface{})[0].(map[string]interface{})["pipeline"])
+}
+
+func TestSavePipelineUnboundReferencesSavedAgain(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+	pipelineJSON := `{
+		"application": "appname",
+		"name": "deploy",
+		"triggers": [{"type": "pipeline", "application": "appname", "pipeline": null}],
+		"stages": [{"name": "Run", "type": "pipeline", "application": "appname", "pipeline": null}]
+	}`
+
+	// Test
+	_, err := client.SavePipeline(pipelineJSON, backend.SaveOptions{AllowUnbound: true})
+
+	// Assert
+	assert.Nil(t, err)
+
+	saved := stub.pipelines[pipelineKey{Application: "appname", Name: "deploy"}]
+	assert.Nil(t, saved["triggers"].([]interface{})[0].(map[string]interface{})["pipeline"])
+	assert.Nil(t, saved["stages"].([]interface{})[0].(map[string]interface{})["pipeline"])
+}
+
+func TestSavePipelineUnboundReferenceFails(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+
+	for _, pipelineJSON := range []string{
+		`{"application": "appname", "name": "deploy", "triggers": [{"type": "pipeline", "application": "appname", "pipeline": null}]}`,
+		`{"application": "appname", "name": "deploy", "stages": [{"name": "Run", "type": "pipeline", "application": "appname", "pipeline": null}]}`,
+	} {
+		// Test
+		_, err := client.SavePipeline(pipelineJSON, backend.SaveOptions{})
+
+		// Assert
+		assert.EqualError(t, err, "a pipeline reference of application \"appname\" is unbound (`null`), use `--allow-unbound` to save unbound references")
+	}
+
+	assert.Len(t, stub.pipelines, 0)
+}
+
+func TestSavePipelineStampsOwnership(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{}}
+	client := newStubClient(stub)
+	owner := &backend.Ownership{Project: "my-project", Source: "main"}
+	pipelineJSON := `
+	{
+		"application": "appname",
+		"name": "parent",
+		"stages": [{"name": "Child", "type": "pipeline", "application": "other", "pipeline": {"application": "other", "name": "child", "stages": []}}]
+	}`
+
+	// Test
+	_, err := client.SavePipeline(pipelineJSON, backend.SaveOptions{Owner: owner})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, owner, backend.GetOwnership(stub.pipelines[pipelineKey{Application: "appname", Name: "parent"}]))
+	assert.Equal(t, owner, backend.GetOwnership(stub.pipelines[pipelineKey{Application: "other", Name: "child"}]))
+}
+
+func TestSavePipelineDoesNotStampPlaceholders(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{
+		{Application: "other", Name: "verify"}: {"application": "other", "name": "verify", "id": "id-verify"},
+	}}
+	client := newStubClient(stub)
+	owner := &backend.Ownership{Project: "my-project", Source: "main"}
+
+	// Test
+	_, err := client.SavePipeline(referencingPipeline, backend.SaveOptions{CreateMissing: true, Owner: owner})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, backend.GetOwnership(stub.pipelines[pipelineKey{Application: "other", Name: "build"}]))
+	assert.Equal(t, owner, backend.GetOwnership(stub.pipelines[pipelineKey{Application: "appname", Name: "deploy"}]))
+}
+
+func TestListPipelines(t *testing.T) {
+	// Given
+	stub := &stubSpinnaker{pipelines: map[pipelineKey]map[string]interface{}{
+		{Application: "appname", Name: "deploy"}: {"application": "appname", "name": "deploy"},
+		{Application: "appname", Name: "build"}:  {"application": "appname", "name": "build"},
+		{Application: "other", Name: "verify"}:   {"application": "other", "name": "verify"},
+	}}
+	client := newStubClient(stub)
+
+	// Test
+	pipelines, err := client.ListPipelines("appname")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, pipelines, 2)
+	assert.Equal(t, "build", pipelines[0]["name"])
+	assert.Equal(t, "deploy", pipelines[1]["name"])
+}
@@ -3,6 +3,8 @@ package spinnaker
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/Autodesk/shore/pkg/shore_testing"
 )
 
 const (
@@ -44,17 +46,23 @@ EXPECTED_OUTPUT failed assertion for stage '%s'
 	got: '%v'
 `
 
-func isExpectedStatus(expectedStatus, status, stageName string) error {
-	switch expectedStatus {
-	// Test if this is one of the expected `states` for a `spinnaker stage` to be in.
-	case PipelineNotStarted, PipelineRunning, PipelinePaused, PipelineSuspended, PipelineSucceeded, PipelineFailedContinue,
-		PipelineTerminal, PipelineCanceled, PipelineRedirect, PipelineStopped, PipelineSkipped, PipelineBuffered:
+func isExpectedStatus(expectedStatus shore_testing.StatusMatcher, status, stageName string) error {
+	if expectedStatus.IsEmpty() {
+		return fmt.Errorf("wrong status: ''")
+	}
 
-		if expectedStatus != status {
-			return fmt.Errorf(statusFailed, stageName, expectedStatus, status)
+	for _, expected := range expectedStatus.Statuses {
+		switch expected {
+		// Test if this is one of the expected `states` for a `spinnaker stage` to be in.
+		case PipelineNotStarted, PipelineRunning, PipelinePaused, PipelineSuspended, PipelineSucceeded, PipelineFailedContinue,
+			PipelineTerminal, PipelineCanceled, PipelineRedirect, PipelineStopped, PipelineSkipped, PipelineBuffered:
+		default:
+			return fmt.Errorf("wrong status: '%s'", expected)
 		}
-	default:
-		return fmt.Errorf("wrong status: '%s'", expectedStatus)
+	}
+
+	if !expectedStatus.Matches(status) {
+		return fmt.Errorf(statusFailed, stageName, expectedStatus, status)
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Autodesk/shore/pkg/shore_testing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,18 +22,52 @@ func Test(t *testing.T) {
 
 func TestIsExpectedStatusSuccess(t *testing.T) {
 	// Test
-	err := isExpectedStatus("TERMINAL", "TERMINAL", "myStage")
+	err := isExpectedStatus(shore_testing.NewStatusMatcher("TERMINAL"), "TERMINAL", "myStage")
 
 	assert.Nil(t, err)
 }
 
 func TestIsExpectedStatusFailure(t *testing.T) {
 	// Test
-	err := isExpectedStatus("FATAILITY", "FATAILITY", "myStage")
+	err := isExpectedStatus(shore_testing.NewStatusMatcher("FATAILITY"), "FATAILITY", "myStage")
 
 	assert.EqualError(t, err, "wrong status: 'FATAILITY'")
 }
 
+func TestIsExpectedStatusCaseInsensitive(t *testing.T) {
+	err := isExpectedStatus(shore_testing.NewStatusMatcher("succeeded"), "SUCCEEDED", "myStage")
+
+	assert.Nil(t, err)
+}
+
+func TestIsExpectedStatusList(t *testing.T) {
+	expectedStatus := shore_testing.NewStatusMatcher("succeeded", "skipped")
+
+	assert.Nil(t, isExpectedStatus(expectedStatus, "SKIPPED", "myStage"))
+	assert.EqualError(
+		t,
+		isExpectedStatus(expectedStatus, "TERMINAL", "myStage"),
+		fmt.Sprintf(statusFailed, "myStage", "one of SUCCEEDED, SKIPPED", "TERMINAL"),
+	)
+}
+
+func TestIsExpectedStatusNot(t *testing.T) {
+	expectedStatus := shore_testing.NewNotStatusMatcher("terminal")
+
+	assert.Nil(t, isExpectedStatus(expectedStatus, "SUCCEEDED", "myStage"))
+	assert.EqualError(
+		t,
+		isExpectedStatus(expectedStatus, "TERMINAL", "myStage"),
+		fmt.Sprintf(statusFailed, "myStage", "not TERMINAL", "TERMINAL"),
+	)
+}
+
+func TestIsExpectedStatusInvalidInList(t *testing.T) {
+	err := isExpectedStatus(shore_testing.NewStatusMatcher("succeeded", "done"), "SUCCEEDED", "myStage")
+
+	assert.EqualError(t, err, "wrong status: 'DONE'")
+}
+
 func TestIsExpectedOutputSuccess(t *testing.T) {
 	expectedOutput := map[string]interface{}{
 		"This": "test",
@@ -0,0 +1,301 @@
+package spinnaker
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Trigger types supported by the trigger simulation (see `trigger.libsonnet`).
+const (
+	TriggerManual    = "manual"
+	TriggerJenkins   = "jenkins"
+	TriggerConcourse = "concourse"
+	TriggerWebhook   = "webhook"
+	TriggerDocker    = "docker"
+	TriggerGit       = "git"
+	TriggerPipeline  = "pipeline"
+)
+
+var strictTriggerJSON = jsoniter.Config{
+	EscapeHTML:            true,
+	SortMapKeys:           true,
+	DisallowUnknownFields: true,
+}.Froze()
+
+// Trigger - The `trigger` section of an execution, simulates the pipeline being triggered by a Jenkins build,
+// a webhook, a docker registry push, a git push or another pipeline.
+//
+// Only the properties relevant to `Type` are used.
+type Trigger struct {
+	Type string `json:"type"`
+	User string `json:"user,omitempty"`
+
+	// Jenkins & Concourse
+	Master      string                 `json:"master,omitempty"`
+	Job         string                 `json:"job,omitempty"`
+	BuildNumber int                    `json:"buildNumber,omitempty"`
+	BuildInfo   map[string]interface{} `json:"buildInfo,omitempty"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+
+	// Webhook (`Source` is also the git provider - I.E. `github`)
+	Source  string                 `json:"source,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+
+	// Docker
+	Account    string `json:"account,omitempty"`
+	Registry   string `json:"registry,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+
+	// Git
+	Project string `json:"project,omitempty"`
+	Slug    string `json:"slug,omitempty"`
+	Branch  string `json:"branch,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+
+	// Pipeline (the parent pipeline)
+	Application string `json:"application,omitempty"`
+	Pipeline    string `json:"pipeline,omitempty"`
+	Status      string `json:"status,omitempty"`
+
+	// ExpectedArtifacts - Defaults to the expected artifacts of the matching pipeline trigger.
+	ExpectedArtifacts []map[string]interface{} `json:"expectedArtifacts,omitempty"`
+}
+
+// requiredTriggerKeys - The keys each trigger type needs to be matched to a pipeline trigger.
+var requiredTriggerKeys = map[string][]string{
+	TriggerManual:    {},
+	TriggerJenkins:   {"master", "job"},
+	TriggerConcourse: {"master", "job"},
+	TriggerWebhook:   {"source"},
+	TriggerDocker:    {"repository", "tag"},
+	TriggerGit:       {"source", "project", "slug"},
+	TriggerPipeline:  {"application", "pipeline"},
+}
+
+// parseTrigger - Decodes and validates the `trigger` section of an execution.
+func parseTrigger(value interface{}) (*Trigger, error) {
+	data, err := jsoniter.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var trigger Trigger
+
+	if err := strictTriggerJSON.Unmarshal(data, &trigger); err != nil {
+		return nil, fmt.Errorf("invalid `trigger`: %w", err)
+	}
+
+	required, supported := requiredTriggerKeys[trigger.Type]
+
+	if !supported {
+		return nil, fmt.Errorf("unsupported trigger type %q, expected one of: manual, jenkins, concourse, webhook, docker, git, pipeline", trigger.Type)
+	}
+
+	values := trigger.keyValues()
+	var missing []string
+
+	for _, key := range required {
+		if values[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("`%s` trigger missing required keys: %s", trigger.Type, strings.Join(missing, ", "))
+	}
+
+	return &trigger, nil
+}
+
+// keyValues - The identifying values of the trigger, by key.
+func (t *Trigger) keyValues() map[string]string {
+	return map[string]string{
+		"master":      t.Master,
+		"job":         t.Job,
+		"source":      t.Source,
+		"repository":  t.Repository,
+		"tag":         t.Tag,
+		"project":     t.Project,
+		"slug":        t.Slug,
+		"application": t.Application,
+		"pipeline":    t.Pipeline,
+	}
+}
+
+// String - A short description of the trigger, I.E. `jenkins trigger (master: ci, job: build)`.
+func (t *Trigger) String() string {
+	values := t.keyValues()
+	var keys []string
+
+	for _, key := range requiredTriggerKeys[t.Type] {
+		if key == "tag" {
+			continue
+		}
+
+		keys = append(keys, fmt.Sprintf("%s: %s", key, values[key]))
+	}
+
+	if len(keys) == 0 {
+		return fmt.Sprintf("%s trigger", t.Type)
+	}
+
+	return fmt.Sprintf("%s trigger (%s)", t.Type, strings.Join(keys, ", "))
+}
+
+// matches - Whether a trigger of the pipeline configuration is the one being simulated.
+//
+// `parentPipelineID` is the ID of the parent pipeline, for `pipeline` triggers.
+func (t *Trigger) matches(pipelineTrigger map[string]interface{}, parentPipelineID string) bool {
+	if pipelineTrigger["type"] != t.Type {
+		return false
+	}
+
+	stringValue := func(key string) string {
+		value, _ := pipelineTrigger[key].(string)
+		return value
+	}
+
+	switch t.Type {
+	case TriggerJenkins, TriggerConcourse:
+		return stringValue("master") == t.Master && stringValue("job") == t.Job
+	case TriggerWebhook:
+		return stringValue("source") == t.Source
+	case TriggerDocker:
+		return stringValue("repository") == t.Repository && (t.Account == "" || stringValue("account") == t.Account)
+	case TriggerGit:
+		return stringValue("source") == t.Source && stringValue("project") == t.Project && stringValue("slug") == t.Slug
+	case TriggerPipeline:
+		pipeline := stringValue("pipeline")
+		return stringValue("application") == t.Application && (pipeline == t.Pipeline || (parentPipelineID != "" && pipeline == parentPipelineID))
+	}
+
+	return false
+}
+
+// triggerPayload - Builds the trigger fields of the execution request, `pipeline` is the pipeline configuration.
+//
+// Except for `manual` triggers, the pipeline must have a matching trigger, its expected artifacts are sent
+// (unless `expectedArtifacts` is set).
+func (s *SpinClient) triggerPayload(application, pipelineName string, pipeline map[string]interface{}, trigger *Trigger) (map[string]interface{}, error) {
+	payload := map[string]interface{}{"type": trigger.Type}
+
+	if trigger.User != "" {
+		payload["user"] = trigger.User
+	}
+
+	expectedArtifacts := trigger.ExpectedArtifacts
+
+	if trigger.Type != TriggerManual {
+		pipelineTrigger, err := s.findPipelineTrigger(pipeline, trigger)
+
+		if err != nil {
+			return nil, fmt.Errorf("pipeline %q of application %q: %w", pipelineName, application, err)
+		}
+
+		if expectedArtifacts == nil {
+			expectedArtifacts = triggerExpectedArtifacts(pipeline, pipelineTrigger)
+		}
+	}
+
+	switch trigger.Type {
+	case TriggerJenkins, TriggerConcourse:
+		buildInfo := map[string]interface{}{"name": trigger.Job, "number": trigger.BuildNumber}
+
+		for key, value := range trigger.BuildInfo {
+			buildInfo[key] = value
+		}
+
+		payload["master"] = trigger.Master
+		payload["job"] = trigger.Job
+		payload["buildNumber"] = trigger.BuildNumber
+		payload["buildInfo"] = buildInfo
+
+		if trigger.Properties != nil {
+			payload["properties"] = trigger.Properties
+		}
+	case TriggerWebhook:
+		payload["source"] = trigger.Source
+		payload["payload"] = trigger.Payload
+	case TriggerDocker:
+		payload["account"] = trigger.Account
+		payload["registry"] = trigger.Registry
+		payload["repository"] = trigger.Repository
+		payload["tag"] = trigger.Tag
+	case TriggerGit:
+		payload["source"] = trigger.Source
+		payload["project"] = trigger.Project
+		payload["slug"] = trigger.Slug
+		payload["branch"] = trigger.Branch
+		payload["hash"] = trigger.Hash
+	case TriggerPipeline:
+		status := strings.ToUpper(trigger.Status)
+
+		if status == "" {
+			status = PipelineSucceeded
+		}
+
+		payload["parentPipelineApplication"] = trigger.Application
+		payload["parentPipelineName"] = trigger.Pipeline
+		payload["parentExecution"] = map[string]interface{}{
+			"type":        "PIPELINE",
+			"application": trigger.Application,
+			"name":        trigger.Pipeline,
+			"status":      status,
+		}
+	}
+
+	if len(expectedArtifacts) > 0 {
+		expectedArtifactIds := make([]interface{}, 0, len(expectedArtifacts))
+
+		for _, expectedArtifact := range expectedArtifacts {
+			if id, exists := expectedArtifact["id"]; exists {
+				expectedArtifactIds = append(expectedArtifactIds, id)
+			}
+		}
+
+		payload["expectedArtifacts"] = expectedArtifacts
+		payload["expectedArtifactIds"] = expectedArtifactIds
+	}
+
+	return payload, nil
+}
+
+// findPipelineTrigger - Finds the trigger of the pipeline configuration matching the simulated trigger.
+func (s *SpinClient) findPipelineTrigger(pipeline map[string]interface{}, trigger *Trigger) (map[string]interface{}, error) {
+	var parentPipelineID string
+
+	if trigger.Type == TriggerPipeline {
+		parentPipelineID, _, _ = s.getOtherPipelineId(trigger.Application, trigger.Pipeline)
+	}
+
+	pipelineTriggers, _ := pipeline["triggers"].([]interface{})
+
+	for _, pipelineTrigger := range pipelineTriggers {
+		if triggerMap, ok := pipelineTrigger.(map[string]interface{}); ok && trigger.matches(triggerMap, parentPipelineID) {
+			return triggerMap, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s is configured", trigger)
+}
+
+// triggerExpectedArtifacts - The expected artifacts of the pipeline referenced by a trigger (`expectedArtifactIds`).
+func triggerExpectedArtifacts(pipeline, pipelineTrigger map[string]interface{}) []map[string]interface{} {
+	ids, _ := pipelineTrigger["expectedArtifactIds"].([]interface{})
+	pipelineArtifacts, _ := pipeline["expectedArtifacts"].([]interface{})
+	var expectedArtifacts []map[string]interface{}
+
+	for _, id := range ids {
+		for _, pipelineArtifact := range pipelineArtifacts {
+			if artifact, ok := pipelineArtifact.(map[string]interface{}); ok && artifact["id"] == id {
+				expectedArtifacts = append(expectedArtifacts, artifact)
+			}
+		}
+	}
+
+	return expectedArtifacts
+}
@@ -0,0 +1,170 @@
+package spinnaker
+
+import (
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/config"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeWithTrigger(t *testing.T, argsJSON string) (map[string]interface{}, error) {
+	_, res, err := cli.ExecutePipeline(argsJSON, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bodyString, _ := ioutil.ReadAll(res.Request.Body)
+	var body map[string]interface{}
+	jsoniter.Unmarshal(bodyString, &body)
+
+	return body, nil
+}
+
+func TestExecManualTrigger(t *testing.T) {
+	// Test
+	body, err := executeWithTrigger(t, `{"application": "test", "pipeline": "test", "trigger": {"type": "manual", "user": "ci-bot"}}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"type": "manual", "user": "ci-bot"}, body)
+}
+
+func TestExecJenkinsTrigger(t *testing.T) {
+	// Test
+	body, err := executeWithTrigger(t, `{
+		"application": "triggered-app",
+		"pipeline": "test",
+		"parameters": {"answer": 42},
+		"trigger": {"type": "jenkins", "master": "ci", "job": "build", "buildNumber": 12, "buildInfo": {"url": "https://ci/build/12"}}
+	}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type":        "jenkins",
+		"master":      "ci",
+		"job":         "build",
+		"buildNumber": float64(12),
+		"buildInfo":   map[string]interface{}{"name": "build", "number": float64(12), "url": "https://ci/build/12"},
+		"parameters":  map[string]interface{}{"answer": float64(42)},
+		"expectedArtifacts": []interface{}{
+			map[string]interface{}{"id": "artifact-id", "matchArtifact": map[string]interface{}{"type": "docker/image"}},
+		},
+		"expectedArtifactIds": []interface{}{"artifact-id"},
+	}, body)
+}
+
+func TestExecWebhookTriggerExpectedArtifactsOverride(t *testing.T) {
+	// Test
+	body, err := executeWithTrigger(t, `{
+		"application": "triggered-app",
+		"pipeline": "test",
+		"trigger": {"type": "webhook", "source": "my-webhook", "payload": {"ref": "main"}, "expectedArtifacts": [{"id": "custom"}]}
+	}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"ref": "main"}, body["payload"])
+	assert.Equal(t, []interface{}{"custom"}, body["expectedArtifactIds"])
+}
+
+func TestExecPipelineTriggerMatchesParentID(t *testing.T) {
+	// Test
+	body, err := executeWithTrigger(t, `{
+		"application": "triggered-app",
+		"pipeline": "child",
+		"trigger": {"type": "pipeline", "application": "triggered-app", "pipeline": "parent"}
+	}`)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "parent", body["parentPipelineName"])
+	assert.Equal(t, map[string]interface{}{
+		"type":        "PIPELINE",
+		"application": "triggered-app",
+		"name":        "parent",
+		"status":      "SUCCEEDED",
+	}, body["parentExecution"])
+}
+
+func TestExecTriggerNotConfigured(t *testing.T) {
+	// Test
+	_, err := executeWithTrigger(t, `{"application": "triggered-app", "pipeline": "test", "trigger": {"type": "jenkins", "master": "ci", "job": "deploy"}}`)
+
+	// Assert
+	assert.EqualError(t, err, `pipeline "test" of application "triggered-app": no jenkins trigger (master: ci, job: deploy) is configured`)
+}
+
+func TestExecTriggerMissingKeys(t *testing.T) {
+	// Test
+	_, err := executeWithTrigger(t, `{"application": "test", "pipeline": "test", "trigger": {"type": "docker", "repository": "org/image"}}`)
+
+	// Assert
+	assert.EqualError(t, err, "`docker` trigger missing required keys: tag")
+}
+
+func TestExecTriggerUnsupportedType(t *testing.T) {
+	// Test
+	_, err := executeWithTrigger(t, `{"application": "test", "pipeline": "test", "trigger": {"type": "cron"}}`)
+
+	// Assert
+	assert.EqualError(t, err, `unsupported trigger type "cron", expected one of: manual, jenkins, concourse, webhook, docker, git, pipeline`)
+}
+
+func TestExecTriggerUnknownKey(t *testing.T) {
+	// Test
+	_, err := executeWithTrigger(t, `{"application": "test", "pipeline": "test", "trigger": {"type": "manual", "usr": "ci-bot"}}`)
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid `trigger`")
+}
+
+func TestTriggerSchemaMatchesTrigger(t *testing.T) {
+	// Given
+	var types []string
+	var keys []string
+
+	for triggerType := range requiredTriggerKeys {
+		types = append(types, triggerType)
+	}
+
+	triggerType := reflect.TypeOf(Trigger{})
+
+	for i := 0; i < triggerType.NumField(); i++ {
+		keys = append(keys, strings.Split(triggerType.Field(i).Tag.Get("json"), ",")[0])
+	}
+
+	sort.Strings(types)
+	sort.Strings(keys)
+
+	for _, name := range []string{"exec", "e2e"} {
+		// Test
+		schema, err := config.LoadSchema(name)
+
+		// Assert
+		assert.Nil(t, err)
+
+		var schemaTypes []string
+		var schemaKeys []string
+
+		for _, value := range schema.Definitions["trigger"].Properties["type"].Enum {
+			schemaTypes = append(schemaTypes, value.(string))
+		}
+
+		for key := range schema.Definitions["trigger"].Properties {
+			schemaKeys = append(schemaKeys, key)
+		}
+
+		sort.Strings(schemaTypes)
+		sort.Strings(schemaKeys)
+
+		assert.Equal(t, types, schemaTypes, name)
+		assert.Equal(t, keys, schemaKeys, name)
+	}
+}
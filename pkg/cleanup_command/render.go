@@ -14,6 +14,7 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewRenderCommand(d *command.Dependencies) *cobra.Command {
 	var values string
+	var renderFlags command.RenderFlags
 
 	cmd := &cobra.Command{
 		Use:   "render",
@@ -22,7 +23,7 @@ func NewRenderCommand(d *command.Dependencies) *cobra.Command {
 This helper utility command is used to debug issues when the "cleanup" pipeline doesn't render correctly.`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settingsBytes, err := config.LoadConfig(d.Project, values, "cleanup/render")
+			settingsBytes, err := config.LoadProfileConfig(d.Project, values, "cleanup/render", command.ProfileName(cmd))
 
 			var confErr *config.FileConfErr
 
@@ -30,6 +31,12 @@ This helper utility command is used to debug issues when the "cleanup" pipeline
 				return err
 			}
 
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
 			pipeline, err := command.Render(d, settingsBytes, renderer.CleanUpFileName)
 			if err != nil {
 				return err
@@ -40,7 +47,9 @@ This helper utility command is used to debug issues when the "cleanup" pipeline
 		},
 	}
 
-	cmd.Flags().StringVarP(&values, "values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&values, "values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+
+	renderFlags.AddFlags(cmd)
 
 	return cmd
 }
@@ -3,6 +3,7 @@ package cleanup_command
 import (
 	"fmt"
 
+	"github.com/Autodesk/shore/pkg/backend"
 	"github.com/Autodesk/shore/pkg/command"
 	"github.com/Autodesk/shore/pkg/config"
 	"github.com/Autodesk/shore/pkg/renderer"
@@ -13,6 +14,8 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewSaveCommand(d *command.Dependencies) *cobra.Command {
 	var renderValues string
+	var renderFlags command.RenderFlags
+	var saveOptions backend.SaveOptions
 
 	cmd := &cobra.Command{
 		Use:   "save",
@@ -21,7 +24,13 @@ func NewSaveCommand(d *command.Dependencies) *cobra.Command {
 Help in developing and debugging cleanup pipelines in a live environment.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settingsBytes, _ := config.LoadConfig(d.Project, renderValues, "cleanup/render")
+			settingsBytes, _ := config.LoadProfileConfig(d.Project, renderValues, "cleanup/render", command.ProfileName(cmd))
+
+			settingsBytes, err := renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
 
 			pipeline, err := command.Render(d, settingsBytes, renderer.CleanUpFileName)
 
@@ -29,8 +38,12 @@ Help in developing and debugging cleanup pipelines in a live environment.
 				return err
 			}
 
+			if saveOptions.Owner, err = command.ProjectOwnership(d, renderer.CleanUpFileName, command.ProfileName(cmd)); err != nil {
+				return err
+			}
+
 			d.Logger.Info("Calling Backend.SavePipeline")
-			res, err := d.Backend.SavePipeline(pipeline)
+			res, err := d.Backend.SavePipeline(pipeline, saveOptions)
 
 			if err != nil {
 				d.Logger.Warn("Save pipeline returned an error", err)
@@ -43,7 +56,10 @@ Help in developing and debugging cleanup pipelines in a live environment.
 		},
 	}
 
-	cmd.Flags().StringVarP(&renderValues, "render-values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&renderValues, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+
+	renderFlags.AddFlags(cmd)
+	command.AddSaveFlags(cmd, &saveOptions)
 
 	return cmd
 }
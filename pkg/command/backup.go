@@ -0,0 +1,375 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/fatih/color"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// BackupManifestFileName - The file describing the pipelines of a backup.
+const BackupManifestFileName = "manifest.json"
+
+// BackupManifest - Describes the pipelines of a backup (`manifest.json`).
+type BackupManifest struct {
+	Application string           `json:"application"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Pipelines   []BackupPipeline `json:"pipelines"`
+}
+
+// BackupPipeline - A pipeline of a backup, its configuration is stored as-is in `File`.
+type BackupPipeline struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	File string `json:"file"`
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// NewBackupCommand - Downloads the pipelines of an application.
+func NewBackupCommand(d *Dependencies) *cobra.Command {
+	var application string
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the pipelines of an application",
+		Long: `Download the configurations of all the pipelines of an application as JSON files, described by a manifest.json file.
+The backup can be restored with "shore restore <dir>".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out == "" {
+				out = filepath.Join("backups", fmt.Sprintf("%s-%s", unsafeFileNameChars.ReplaceAllString(application, "_"), time.Now().UTC().Format("20060102T150405Z")))
+			}
+
+			backupPath, err := projectRelativePath(d, out)
+
+			if err != nil {
+				return err
+			}
+
+			manifest, err := Backup(d, application, backupPath)
+
+			if err != nil {
+				return err
+			}
+
+			color.Green("Backed up %d pipelines of application %q to %s", len(manifest.Pipelines), application, backupPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&application, "application", "", "The application to back up.")
+	cmd.Flags().StringVar(&out, "out", "", "The backup directory (default \"backups/<application>-<timestamp>\").")
+	cmd.MarkFlagRequired("application")
+
+	return cmd
+}
+
+// Backup - Writes the pipelines of an application and their manifest to `backupPath`.
+func Backup(d *Dependencies, application, backupPath string) (*BackupManifest, error) {
+	pipelines, err := d.Backend.ListPipelines(application)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, _ := afero.Exists(d.Project.FS, filepath.Join(backupPath, BackupManifestFileName)); exists {
+		return nil, fmt.Errorf("refusing to overwrite the existing backup in %s", backupPath)
+	}
+
+	if err := d.Project.FS.MkdirAll(backupPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	manifest := &BackupManifest{Application: application, CreatedAt: time.Now().UTC()}
+	usedFiles := make(map[string]bool)
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+
+	for _, pipeline := range pipelines {
+		name, _ := pipeline["name"].(string)
+		id, _ := pipeline["id"].(string)
+		fileName := backupFileName(name, usedFiles)
+
+		content, err := json.MarshalIndent(pipeline, "", "  ")
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := afero.WriteFile(d.Project.FS, filepath.Join(backupPath, fileName), content, 0644); err != nil {
+			return nil, err
+		}
+
+		manifest.Pipelines = append(manifest.Pipelines, BackupPipeline{Name: name, ID: id, File: fileName})
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, afero.WriteFile(d.Project.FS, filepath.Join(backupPath, BackupManifestFileName), content, 0644)
+}
+
+// backupFileName - A unique file name for a pipeline, pipeline names may hold any character.
+func backupFileName(pipelineName string, usedFiles map[string]bool) string {
+	base := unsafeFileNameChars.ReplaceAllString(pipelineName, "_")
+
+	if base == "" || base == strings.TrimSuffix(BackupManifestFileName, ".json") {
+		base = "pipeline"
+	}
+
+	fileName := base + ".json"
+
+	for i := 2; usedFiles[fileName]; i++ {
+		fileName = fmt.Sprintf("%s-%d.json", base, i)
+	}
+
+	usedFiles[fileName] = true
+
+	return fileName
+}
+
+// NewRestoreCommand - Saves the pipelines of a backup.
+func NewRestoreCommand(d *Dependencies) *cobra.Command {
+	var dryRun bool
+	var saveOptions backend.SaveOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore <dir>",
+		Short: "Restore the pipelines of a backup",
+		Long: `Save the pipelines of a backup (created by "shore backup").
+Pipelines are saved the same way "shore save" saves them, references between the pipelines of the backup are resolved by name.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backupPath, err := projectRelativePath(d, args[0])
+
+			if err != nil {
+				return err
+			}
+
+			manifest, pipelines, err := LoadBackup(d, backupPath)
+
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				changes, err := RestoreChanges(d, pipelines)
+
+				if err != nil {
+					return err
+				}
+
+				color.Yellow(fmt.Sprintf("Application: %s", manifest.Application))
+
+				for _, change := range changes {
+					color.Yellow(change.String())
+				}
+
+				return nil
+			}
+
+			pipelinesJSON, err := jsoniter.MarshalToString(RestorablePipelines(manifest, pipelines))
+
+			if err != nil {
+				return err
+			}
+
+			d.Logger.Info("Calling Backend.SavePipeline")
+			res, err := d.Backend.SavePipeline(pipelinesJSON, saveOptions)
+
+			if err != nil {
+				d.Logger.Warnf("Save pipeline returned an error: %v", err)
+				return err
+			}
+
+			d.Logger.Info("Backend.SavePipeline returned")
+			fmt.Println(res)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "List the pipelines that would be created or updated, without saving them.")
+
+	AddSaveFlags(cmd, &saveOptions)
+
+	return cmd
+}
+
+// LoadBackup - Reads the manifest and the pipelines of a backup.
+func LoadBackup(d *Dependencies, backupPath string) (*BackupManifest, []map[string]interface{}, error) {
+	content, err := afero.ReadFile(d.Project.FS, filepath.Join(backupPath, BackupManifestFileName))
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s is not a backup: %w", backupPath, err)
+	}
+
+	var manifest BackupManifest
+
+	if err := jsoniter.Unmarshal(content, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid backup manifest %s: %w", filepath.Join(backupPath, BackupManifestFileName), err)
+	}
+
+	pipelines := make([]map[string]interface{}, 0, len(manifest.Pipelines))
+
+	for _, backupPipeline := range manifest.Pipelines {
+		content, err := afero.ReadFile(d.Project.FS, filepath.Join(backupPath, backupPipeline.File))
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var pipeline map[string]interface{}
+
+		if err := jsoniter.Unmarshal(content, &pipeline); err != nil {
+			return nil, nil, fmt.Errorf("invalid backup pipeline %s: %w", backupPipeline.File, err)
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return &manifest, pipelines, nil
+}
+
+// RestorablePipelines - The pipelines of a backup, references to the pipelines of the backup (by ID) are replaced
+// with their names, so they are resolved to the IDs of the restored pipelines.
+func RestorablePipelines(manifest *BackupManifest, pipelines []map[string]interface{}) []map[string]interface{} {
+	names := make(map[string]string, len(manifest.Pipelines))
+
+	for _, backupPipeline := range manifest.Pipelines {
+		if backupPipeline.ID != "" {
+			names[backupPipeline.ID] = backupPipeline.Name
+		}
+	}
+
+	for _, pipeline := range pipelines {
+		for _, key := range []string{"triggers", "stages"} {
+			references, _ := pipeline[key].([]interface{})
+
+			for _, reference := range references {
+				referenceMap, isMap := reference.(map[string]interface{})
+
+				if !isMap || referenceMap["type"] != "pipeline" || referenceMap["application"] != manifest.Application {
+					continue
+				}
+
+				if id, isString := referenceMap["pipeline"].(string); isString && names[id] != "" {
+					referenceMap["pipeline"] = names[id]
+				}
+			}
+		}
+	}
+
+	return pipelines
+}
+
+// RestoreChange - How restoring a pipeline changes the backend.
+type RestoreChange struct {
+	Name string
+	// Action - `create`, `update` or `unchanged`.
+	Action string
+	// Keys - The top level keys that are updated.
+	Keys []string
+}
+
+func (c RestoreChange) String() string {
+	if len(c.Keys) > 0 {
+		return fmt.Sprintf("%s: %s (%s)", c.Name, c.Action, strings.Join(c.Keys, ", "))
+	}
+
+	return fmt.Sprintf("%s: %s", c.Name, c.Action)
+}
+
+// RestoreChanges - Compares the pipelines of a backup with the current pipelines (ignoring the Spinnaker generated fields).
+func RestoreChanges(d *Dependencies, pipelines []map[string]interface{}) ([]RestoreChange, error) {
+	changes := make([]RestoreChange, 0, len(pipelines))
+
+	for _, pipeline := range pipelines {
+		application, _ := pipeline["application"].(string)
+		name, _ := pipeline["name"].(string)
+		// Only a pipeline that doesn't exist (404) is created, other errors fail the dry run.
+		current, err := getBackendPipeline(d, application, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			changes = append(changes, RestoreChange{Name: name, Action: "create"})
+			continue
+		}
+
+		wanted := copyPipeline(pipeline)
+		cleanKeys(wanted)
+		cleanKeys(current)
+
+		keys := changedKeys(current, wanted)
+
+		if len(keys) == 0 {
+			changes = append(changes, RestoreChange{Name: name, Action: "unchanged"})
+			continue
+		}
+
+		changes = append(changes, RestoreChange{Name: name, Action: "update", Keys: keys})
+	}
+
+	return changes, nil
+}
+
+// changedKeys - The sorted top level keys that differ between two pipelines.
+func changedKeys(current, wanted map[string]interface{}) []string {
+	var keys []string
+
+	for key, value := range wanted {
+		if !reflect.DeepEqual(current[key], value) {
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range current {
+		if _, exists := wanted[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func copyPipeline(pipeline map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(pipeline))
+
+	for key, value := range pipeline {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+// projectRelativePath - Paths that aren't absolute are relative to the project.
+func projectRelativePath(d *Dependencies, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	projectPath, err := d.Project.GetProjectPath()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(projectPath, path), nil
+}
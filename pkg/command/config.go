@@ -0,0 +1,219 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Autodesk/shore/pkg/config"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// NewConfigCommand - Creates the `config` subcommand, inspecting and editing the project's configuration.
+func NewConfigCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Collection of configuration related commands",
+	}
+
+	cmd.AddCommand(NewConfigViewCommand(d))
+	cmd.AddCommand(NewConfigGetCommand(d))
+	cmd.AddCommand(NewConfigSetCommand(d))
+	cmd.AddCommand(NewConfigValidateCommand(d))
+	cmd.AddCommand(NewConfigEncryptCommand(d))
+
+	return cmd
+}
+
+// NewConfigViewCommand - Shows the resolved configuration of the selected profile and executor config.
+func NewConfigViewCommand(d *Dependencies) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show the resolved configuration",
+		Long:  "Show the configuration shore uses for the selected profile and executor config, with the source of every value (secrets are masked).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profileName, profileSource := selectedConfigName(cmd, "profile", "SHORE_PROFILE")
+			executorConfigName, executorConfigSource := selectedConfigName(cmd, "executor-config", "SHORE_EXECUTOR_CONFIG")
+
+			values, err := config.ResolveConfig(d.Project, profileName, executorConfigName)
+
+			if err != nil {
+				return err
+			}
+
+			// Interpolated values (I.E. `${env:TOKEN}`) are secrets.
+			for i, value := range values {
+				if stringValue, ok := value.Value.(string); ok {
+					values[i].Value = config.MaskSecrets(stringValue)
+				}
+			}
+
+			values = append([]config.ConfigValue{
+				{Key: "profile", Value: profileName, Source: profileSource},
+				{Key: "executor-config", Value: executorConfigName, Source: executorConfigSource},
+			}, values...)
+
+			switch output {
+			case "json":
+				data, err := jsoniter.MarshalIndent(values, "", "  ")
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			case "table":
+				writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE")
+
+				for _, value := range values {
+					fmt.Fprintf(writer, "%s\t%v\t%s\n", value.Key, value.Value, value.Source)
+				}
+
+				return writer.Flush()
+			}
+
+			return fmt.Errorf("unsupported output %q, expected one of: table, json", output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "The output format (one of: table, json).")
+
+	return cmd
+}
+
+// NewConfigGetCommand - Prints a value of the project's shore config.
+func NewConfigGetCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a shore.yml value",
+		Long:  "Print a shore.yml value, nested keys are separated by dots (I.E. `shore config get profiles.default.render`).",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := config.GetShoreConfigValue(d.Project, args[0])
+
+			if err != nil {
+				return err
+			}
+
+			if stringValue, ok := value.(string); ok {
+				fmt.Fprintln(cmd.OutOrStdout(), stringValue)
+				return nil
+			}
+
+			data, err := yaml.Marshal(value)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), string(data))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewConfigSetCommand - Sets a value of the project's shore config.
+func NewConfigSetCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a shore.yml value",
+		Long: `Set a shore.yml value, nested keys are separated by dots (I.E. "shore config set executor.config.prod ~/.spin/prod").
+The value is parsed as YAML and the resulting shore.yml is validated before it is written.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return config.SetShoreConfigValue(d.Project, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+// NewConfigValidateCommand - Validates the project's shore config.
+func NewConfigValidateCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate shore.yml",
+		Long:  "Validate shore.yml against its schema and check the configuration files of the profiles exist.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.ValidateShoreConfig(d.Project); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "The configuration is valid!")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewConfigEncryptCommand - Encrypts a secret for the `encrypted-file` interpolation provider.
+func NewConfigEncryptCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt <file>",
+		Short: "Encrypt a secret read from stdin into a file",
+		Long: fmt.Sprintf(`Encrypt a secret read from stdin into a file, using the key in $%s (base64, 32 bytes).
+Reference the secret in a configuration file with "${encrypted-file:<file>}" (or "${encrypted-file:<file>#<key>}" when the secret is a YAML/JSON object).`, config.SecretsKeyEnvVar),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			secret, err := io.ReadAll(cmd.InOrStdin())
+
+			if err != nil {
+				return err
+			}
+
+			encrypted, err := config.EncryptSecret(string(secret))
+
+			if err != nil {
+				return err
+			}
+
+			filePath := args[0]
+
+			if !filepath.IsAbs(filePath) {
+				projectPath, err := d.Project.GetProjectPath()
+
+				if err != nil {
+					return err
+				}
+
+				filePath = filepath.Join(projectPath, filePath)
+			}
+
+			return afero.WriteFile(d.Project.FS, filePath, []byte(encrypted+"\n"), 0600)
+		},
+	}
+
+	return cmd
+}
+
+// ProfileName - The profile selected with `--profile` or `$SHORE_PROFILE`.
+func ProfileName(cmd *cobra.Command) string {
+	profileName, _ := selectedConfigName(cmd, "profile", "SHORE_PROFILE")
+	return profileName
+}
+
+// selectedConfigName - The configuration name selected by a flag or an environment variable, and where it comes from.
+// Follows the priority of the root command: flag, environment variable, `default`.
+func selectedConfigName(cmd *cobra.Command, flagName string, envVar string) (string, string) {
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed && flag.Value.String() != "" {
+		return flag.Value.String(), "--" + flagName
+	}
+
+	if envValue := os.Getenv(envVar); envValue != "" {
+		return envValue, "$" + envVar
+	}
+
+	return "default", config.DefaultSource
+}
@@ -17,6 +17,7 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewDeleteCommand(d *Dependencies) *cobra.Command {
 	var renderVals string
+	var renderFlags RenderFlags
 	var dryRun bool
 
 	cmd := &cobra.Command{
@@ -24,12 +25,18 @@ func NewDeleteCommand(d *Dependencies) *cobra.Command {
 		Short: "Delete the pipeline",
 		Long:  "Using the main file configured by the renderer delete the pipeline (or pipelines)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settingsBytes, err := config.LoadConfig(d.Project, renderVals, "render")
+			settingsBytes, err := config.LoadProfileConfig(d.Project, renderVals, "render", ProfileName(cmd))
 
 			if err != nil && !errors.Is(err, os.ErrNotExist) {
 				return err
 			}
 
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
 			pipeline, err := Render(d, settingsBytes, renderer.MainFileName)
 
 			if err != nil {
@@ -37,16 +44,19 @@ func NewDeleteCommand(d *Dependencies) *cobra.Command {
 			}
 
 			if dryRun {
-				pipelineNames, application, err := d.Backend.GetPipelinesNamesAndApplication(pipeline)
+				applications, err := d.Backend.GetPipelinesNamesByApplication(pipeline)
 
 				if err != nil {
-					d.Logger.Error("could not get pipelines names and application from the configuration")
+					d.Logger.Error("could not get pipelines names and applications from the configuration")
 					return err
 				}
 
-				color.Yellow(fmt.Sprintf("Application: %s", application))
-				color.Yellow(fmt.Sprintf("Pipelines to delete: %s", pipelineNames))
-				d.Logger.Info("Backend.GetPipelinesNamesAndApplication returned")
+				for _, application := range applications {
+					color.Yellow(fmt.Sprintf("Application: %s", application.Application))
+					color.Yellow(fmt.Sprintf("Pipelines to delete: %s", application.Names))
+				}
+
+				d.Logger.Info("Backend.GetPipelinesNamesByApplication returned")
 				return nil
 			}
 
@@ -68,8 +78,10 @@ func NewDeleteCommand(d *Dependencies) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
 	cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "list pipelines to be deleted - dry run")
 
+	renderFlags.AddFlags(cmd)
+
 	return cmd
 }
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/spf13/cobra"
+)
+
+// NewDepsCommand - Creates the `deps` subcommand, managing the project's shared libraries.
+func NewDepsCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Collection of shared libraries related commands",
+	}
+
+	cmd.AddCommand(NewDepsInstallCommand(d))
+	cmd.AddCommand(NewDepsUpdateCommand(d))
+	cmd.AddCommand(NewDepsListCommand(d))
+
+	return cmd
+}
+
+// NewDepsInstallCommand - Installs the project's shared libraries at their locked versions.
+func NewDepsInstallCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the shared libraries",
+		Long:  "Install the shared libraries of the project at their locked versions (resolving the libraries that are not locked yet).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dependencyManager, projectPath, err := getDependencyManager(d)
+
+			if err != nil {
+				return err
+			}
+
+			return dependencyManager.InstallDependencies(projectPath)
+		},
+	}
+
+	return cmd
+}
+
+// NewDepsUpdateCommand - Updates the project's shared libraries to their latest versions.
+func NewDepsUpdateCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update [names...]",
+		Short: "Update the shared libraries",
+		Long:  "Update the shared libraries of the project to their latest versions, all the libraries are updated when no names are provided.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dependencyManager, projectPath, err := getDependencyManager(d)
+
+			if err != nil {
+				return err
+			}
+
+			return dependencyManager.UpdateDependencies(projectPath, args)
+		},
+	}
+
+	return cmd
+}
+
+// NewDepsListCommand - Lists the project's shared libraries.
+func NewDepsListCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the shared libraries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dependencyManager, projectPath, err := getDependencyManager(d)
+
+			if err != nil {
+				return err
+			}
+
+			dependencies, err := dependencyManager.ListDependencies(projectPath)
+
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "NAME\tVERSION\tLOCKED")
+
+			for _, dependency := range dependencies {
+				locked := dependency.LockedVersion
+
+				if locked == "" {
+					locked = "(not installed)"
+				}
+
+				fmt.Fprintf(writer, "%s\t%s\t%s\n", dependency.Name, dependency.Version, locked)
+			}
+
+			return writer.Flush()
+		},
+	}
+
+	return cmd
+}
+
+func getDependencyManager(d *Dependencies) (renderer.DependencyManager, string, error) {
+	dependencyManager, ok := d.Renderer.(renderer.DependencyManager)
+
+	if !ok {
+		return nil, "", fmt.Errorf("the renderer does not support managing dependencies")
+	}
+
+	projectPath, err := d.Project.GetProjectPath()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dependencyManager, projectPath, nil
+}
@@ -3,12 +3,18 @@ package command
 import (
 	"errors"
 	"fmt"
-	"reflect"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	jsoniter "github.com/json-iterator/go"
 
+	"github.com/Autodesk/shore/pkg/backend"
 	"github.com/Autodesk/shore/pkg/config"
+	"github.com/Autodesk/shore/pkg/diff"
 	"github.com/Autodesk/shore/pkg/renderer"
 	"github.com/nsf/jsondiff"
 	"github.com/spf13/cobra"
@@ -18,15 +24,25 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewDiffCommand(d *Dependencies) *cobra.Command {
 	var renderValues string
+	var renderFlags RenderFlags
 	var skipMatches string
+	var revision int
+	var output string
+	var ignore []string
+	var againstProfile string
+	var againstFile string
+	var againstGitRef string
 
 	cmd := &cobra.Command{
 		Use:   "diff",
 		Short: "Difference between current and desired state.",
-		Long:  `Shows difference between current and desired state of the pipeline.`,
+		Long: `Shows difference between current and desired state of the pipeline.
+Use "--revision N" to compare the desired state with a previous revision (see "shore history").
+The Spinnaker generated fields, "diff.ignore" of shore.yml and "--ignore" paths are ignored, stages are compared by "refId" or "name".
+Use "--against-profile", "--against-file" or "--against-git-ref" to compare with another render instead of the backend.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			settingsBytes, err := config.LoadConfig(d.Project, renderValues, "render")
+			settingsBytes, err := config.LoadProfileConfig(d.Project, renderValues, "render", ProfileName(cmd))
 
 			var confErr *config.ConfigurationErr
 
@@ -34,7 +50,41 @@ func NewDiffCommand(d *Dependencies) *cobra.Command {
 				return err
 			}
 
-			err = Diff(d, settingsBytes, skipMatches, renderer.MainFileName)
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
+			configIgnore, err := config.LoadDiffIgnorePaths(d.Project)
+
+			if err != nil {
+				return err
+			}
+
+			var against *DiffSource
+
+			switch {
+			case againstProfile != "":
+				against, err = DiffAgainstProfile(d, againstProfile, renderValues, renderFlags)
+			case againstFile != "":
+				against, err = DiffAgainstFile(d, againstFile)
+			case againstGitRef != "":
+				against, err = DiffAgainstGitRef(d, againstGitRef, ProfileName(cmd), renderValues, renderFlags)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			err = Diff(d, settingsBytes, renderer.MainFileName, DiffOptions{
+				SkipMatches: skipMatches == "true",
+				Revision:    revision,
+				Output:      output,
+				Ignore:      append(configIgnore, ignore...),
+				Against:     against,
+				Out:         cmd.OutOrStdout(),
+			})
 
 			if err != nil {
 				return err
@@ -44,17 +94,99 @@ func NewDiffCommand(d *Dependencies) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&renderValues, "values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&renderValues, "values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
 	cmd.Flags().StringVarP(&skipMatches, "skip", "s", "false", "If true, skip the matching parts in the command output, default is false.")
+	cmd.Flags().IntVar(&revision, "revision", 0, "The revision to compare with (see \"shore history\"), 0 is the current configuration.")
+	cmd.Flags().StringVarP(&output, "output", "o", DiffOutputConsole, fmt.Sprintf("The output format (one of: %s, %s).", DiffOutputConsole, strings.Join(diff.Formats, ", ")))
+	cmd.Flags().StringArrayVar(&ignore, "ignore", []string{}, "A path ignored when comparing the pipelines (I.E. \"triggers[*].lastSuccessfulExecution\"). May be repeated.")
+	cmd.Flags().StringVar(&againstProfile, "against-profile", "", "Compare with the render of another profile (I.E. \"prod\") instead of the backend.")
+	cmd.Flags().StringVar(&againstFile, "against-file", "", "Compare with the pipelines of a JSON file (I.E. a saved render, relative to the project) instead of the backend.")
+	cmd.Flags().StringVar(&againstGitRef, "against-git-ref", "", "Compare with the render of a git ref (I.E. \"main\") instead of the backend.")
+	cmd.MarkFlagsMutuallyExclusive("against-profile", "against-file", "against-git-ref", "revision")
+
+	renderFlags.AddFlags(cmd)
 
 	return cmd
 }
 
+// DiffOutputConsole - The default output of `Diff`, the side by side JSON comparison, other outputs are `diff.Formats`.
+const DiffOutputConsole = "console"
+
+// DiffOptions - How `Diff` compares and prints the pipelines.
+type DiffOptions struct {
+	// SkipMatches - Skip the matching parts of the console output.
+	SkipMatches bool
+	// Revision - A revision other than 0 compares the desired state with a previous revision of the pipeline (and its nested pipelines).
+	Revision int
+	// Output - `DiffOutputConsole` (the default) or one of `diff.Formats`.
+	Output string
+	// Ignore - The paths ignored on top of `diff.DefaultIgnorePaths`.
+	Ignore []string
+	// Against - Compares with the pipelines of another source instead of the backend, see `DiffSource`.
+	Against *DiffSource
+	// Out - Where the JSON patch document (`diff.FormatJSONPatch`) is written, `os.Stdout` when nil.
+	Out io.Writer
+}
+
+// DiffRenderErr - The project couldn't be rendered, or the rendered pipelines couldn't be parsed.
+type DiffRenderErr struct {
+	Err error
+}
+
+func (e *DiffRenderErr) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DiffRenderErr) Unwrap() error {
+	return e.Err
+}
+
+// DiffPipelineErr - The backend failed to return the current configuration of a pipeline.
+type DiffPipelineErr struct {
+	Application string
+	Pipeline    string
+	Err         error
+}
+
+func (e *DiffPipelineErr) Error() string {
+	return fmt.Sprintf("failed to get the pipeline %q of application %q: %v", e.Pipeline, e.Application, e.Err)
+}
+
+func (e *DiffPipelineErr) Unwrap() error {
+	return e.Err
+}
+
+// InvalidPipelineErr - A rendered pipeline has no application or name, the pipelines are compared by application & name.
+type InvalidPipelineErr struct {
+	// Pipeline - The name of the pipeline, empty when it has no name.
+	Pipeline string
+	// Key - The missing key, `application` or `name`.
+	Key string
+}
+
+func (e *InvalidPipelineErr) Error() string {
+	if e.Pipeline == "" {
+		return fmt.Sprintf("a rendered pipeline has no %q (string), it is required to compare the pipeline", e.Key)
+	}
+
+	return fmt.Sprintf("the rendered pipeline %q has no %q (string), it is required to compare the pipeline", e.Pipeline, e.Key)
+}
+
 // Diff Using a Project & Renderer & Get, renders the pipeline and shows the difference between current and desired state.
-func Diff(d *Dependencies, settings []byte, skipMatches string, renderType renderer.RenderType) error {
+//
+// The pipelines are compared by the application & name of the rendered pipelines, pipelines that don't exist yet are
+// reported as created. The `diff.FormatJSONPatch` output is a single JSON document, the patch of each pipeline keyed
+// by `<application>/<name>`. Fails with a `DiffRenderErr`, `DiffPipelineErr` or `InvalidPipelineErr`.
+func Diff(d *Dependencies, settings []byte, renderType renderer.RenderType, options DiffOptions) error {
 	// TODO: For future DevX, aggregate errors and return them together.
 	d.Logger.Info("Diff function started")
 
+	compareOptions, err := diffCompareOptions(options)
+
+	if err != nil {
+		return err
+	}
+
 	d.Logger.Debug("GetProjectPath")
 	projectPath, err := d.Project.GetProjectPath()
 	d.Logger.Debug("GetProjectPath returned ", projectPath)
@@ -69,74 +201,186 @@ func Diff(d *Dependencies, settings []byte, skipMatches string, renderType rende
 	d.Logger.Debug("Args returned:\n", renderArgs)
 	d.Logger.Info("calling Renderer.Render with projectPath ", projectPath, " and renderArgs ", renderArgs)
 
-	desiredPipelineString, desiredPipelineInterface := getDesiredPipeline(d, projectPath, renderArgs, renderType)
+	desiredPipelines, err := getDesiredPipelines(d, projectPath, renderArgs, renderType)
 
-	IDToPipelineMap := make(map[string]interface{})
-	fillPipelineMap(d, IDToPipelineMap, desiredPipelineInterface)
+	if err != nil {
+		return err
+	}
 
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
-	args := make(map[string]interface{})
-	err = json.UnmarshalFromString(renderArgs, &args)
+	patches := make(map[string][]diff.Change)
+
+	for _, desiredPipelineInterface := range desiredPipelines {
+		application, pipeline, err := renderedPipelineName(desiredPipelineInterface)
+
+		if err != nil {
+			return err
+		}
+
+		desiredPipelineString, err := json.Marshal(desiredPipelineInterface)
+
+		if err != nil {
+			d.Logger.Error("json.Marshal Could not marshal the desired pipeline ", err)
+			return err
+		}
+
+		var currentPipelineString []byte
+
+		if options.Against != nil {
+			currentPipelineString, err = getAgainstPipeline(options.Against, application, pipeline)
+		} else {
+			currentPipelineString, err = getCurrentPipeline(d, application, pipeline, desiredPipelineInterface, options.Revision)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := diffAndPrint(application, pipeline, currentPipelineString, desiredPipelineString, options, compareOptions, patches); err != nil {
+			return err
+		}
+	}
+
+	if options.Against != nil {
+		if err := diffRemovedPipelines(desiredPipelines, options, compareOptions, patches); err != nil {
+			return err
+		}
+	}
+
+	if options.Output == diff.FormatJSONPatch {
+		return printPatches(options.Out, patches)
+	}
+
+	return nil
+}
+
+// printPatches Print the JSON patches of the pipelines as one JSON document, as-is so it can be piped to other tools
+func printPatches(out io.Writer, patches map[string][]diff.Change) error {
+	document, err := diff.JSONPatches(patches)
 
 	if err != nil {
-		d.Logger.Error("json.Unmarshal Could not unmarshell the rendered file ", err)
 		return err
 	}
 
-	currentPipelineString, _ := getCurrentPipeline(d, args, IDToPipelineMap)
+	if out == nil {
+		out = os.Stdout
+	}
+
+	_, err = fmt.Fprintln(out, string(document))
+	return err
+}
+
+// diffRemovedPipelines Print the pipelines of the source (`DiffOptions.Against`) that aren't rendered
+func diffRemovedPipelines(desiredPipelines []map[string]interface{}, options DiffOptions, compareOptions diff.Options, patches map[string][]diff.Change) error {
+	rendered := &DiffSource{Pipelines: desiredPipelines}
+
+	for _, againstPipeline := range options.Against.Pipelines {
+		application, _ := againstPipeline["application"].(string)
+		name, _ := againstPipeline["name"].(string)
+
+		if rendered.find(application, name) != nil {
+			continue
+		}
+
+		againstPipelineString, err := getAgainstPipeline(options.Against, application, name)
+
+		if err != nil {
+			return err
+		}
+
+		if err := diffAndPrint(application, name, againstPipelineString, nil, options, compareOptions, patches); err != nil {
+			return err
+		}
+	}
 
-	diffAndPrint(args, currentPipelineString, desiredPipelineString, skipMatches)
 	return nil
 }
 
+// renderedPipelineName Returns the application & name of a rendered pipeline
+func renderedPipelineName(pipeline map[string]interface{}) (string, string, error) {
+	application, _ := pipeline["application"].(string)
+	name, _ := pipeline["name"].(string)
+
+	if name == "" {
+		return "", "", &InvalidPipelineErr{Key: "name"}
+	}
+
+	if application == "" {
+		return "", "", &InvalidPipelineErr{Pipeline: name, Key: "application"}
+	}
+
+	return application, name, nil
+}
+
 /*
 fillPipelineMap Populate the IDToPipelineMap (map[id] -> current pipeline configuration)
 This function is needed to swap the content of nested pipelines gathered from spinnaker API
 from Ids of to their full objects or names respectively to the kind NestedPipelineStage vs PipelineStage.
 */
 func fillPipelineMap(d *Dependencies, IDToPipelineMap map[string]interface{},
-	parentPipeline map[string]interface{}) {
-
-	var nestedApplicationName string
-	var nestedPipelineName string
-	var nestedPipelineObject map[string]interface{}
+	parentPipeline map[string]interface{}) error {
 
-	stages, exists := parentPipeline["stages"]
-	if !exists {
-		return
-	}
+	stages, _ := parentPipeline["stages"].([]interface{})
 
-	for _, stage := range stages.([]interface{}) {
-		stage := stage.(map[string]interface{})
+	for _, stage := range stages {
+		stage, _ := stage.(map[string]interface{})
 
 		// if there is a nested pipeline in the local configuration
-		if nestedPipeline, exists := stage["pipeline"]; exists {
-			nestedApplicationName = stage["application"].(string)
-			// if this pipeline is not a string it is a kind of NestedPipelineStage which is an object.
-			// vs PipelineStage which is a string (the name of the pipeline)
-			itIsFullObjectPipeline := reflect.TypeOf(nestedPipeline).String() != "string"
-
-			if itIsFullObjectPipeline {
-				nestedPipelineObject = nestedPipeline.(map[string]interface{})
-				nestedPipelineName = nestedPipelineObject["name"].(string)
-			} else {
-				nestedPipelineName = nestedPipeline.(string)
+		nestedPipeline, exists := stage["pipeline"]
+
+		if !exists {
+			continue
+		}
+
+		nestedApplicationName, _ := stage["application"].(string)
+		var nestedPipelineName string
+
+		// if this pipeline is not a string it is a kind of NestedPipelineStage which is an object.
+		// vs PipelineStage which is a string (the name of the pipeline)
+		nestedPipelineObject, itIsFullObjectPipeline := nestedPipeline.(map[string]interface{})
+
+		if itIsFullObjectPipeline {
+			nestedPipelineName, _ = nestedPipelineObject["name"].(string)
+
+			// The child pipeline may belong to another application than its parent.
+			if childApplication, isString := nestedPipelineObject["application"].(string); isString {
+				nestedApplicationName = childApplication
 			}
+		} else {
+			nestedPipelineName, _ = nestedPipeline.(string)
+		}
+
+		// Pipelines referenced by expressions (I.E. SpEL) can't be resolved.
+		if nestedApplicationName == "" || nestedPipelineName == "" {
+			continue
+		}
+
+		currentNestedPipeline, err := getBackendPipeline(d, nestedApplicationName, nestedPipelineName)
+
+		if err != nil {
+			return err
+		}
+
+		// The nested pipeline doesn't exist yet, it will be created with its parent.
+		id, _ := currentNestedPipeline["id"].(string)
+
+		if id == "" {
+			continue
+		}
+
+		// need further recursion only if is type of NestedPipelineStage
+		if itIsFullObjectPipeline {
+			IDToPipelineMap[id] = currentNestedPipeline
 
-			currentNestedPipeline, _, _ := d.Backend.GetPipeline(nestedApplicationName, nestedPipelineName)
-			if currentNestedPipeline != nil {
-				id := currentNestedPipeline["id"].(string)
-				IDToPipelineMap[id] = currentNestedPipeline
-				// need further recursion only if is type of NestedPipelineStage
-				if itIsFullObjectPipeline {
-					IDToPipelineMap[id] = currentNestedPipeline
-					fillPipelineMap(d, IDToPipelineMap, nestedPipelineObject)
-				} else {
-					IDToPipelineMap[id] = currentNestedPipeline["name"].(string)
-				}
+			if err := fillPipelineMap(d, IDToPipelineMap, nestedPipelineObject); err != nil {
+				return err
 			}
+		} else {
+			IDToPipelineMap[id] = currentNestedPipeline["name"]
 		}
 	}
+
+	return nil
 }
 
 // formatCurrentPipeline Format the current pipeline object recursively
@@ -144,101 +388,271 @@ func formatCurrentPipeline(d *Dependencies, IDToPipelineMap map[string]interface
 	parentPipeline map[string]interface{}) {
 	cleanKeys(parentPipeline)
 
-	stages, exists := (parentPipeline)["stages"]
-	if !exists {
-		return
-	}
+	stages, _ := parentPipeline["stages"].([]interface{})
 
-	for _, stage := range stages.([]interface{}) {
-		stage := stage.(map[string]interface{})
-		if nestedPipelineID, exists := stage["pipeline"]; exists {
-			stage["pipeline"] = (IDToPipelineMap)[nestedPipelineID.(string)]
-			nestedPipeline := stage["pipeline"]
-			if nestedPipeline != nil && reflect.TypeOf(nestedPipeline).String() != "string" {
-				formatCurrentPipeline(d, IDToPipelineMap, nestedPipeline.(map[string]interface{}))
+	for _, stage := range stages {
+		stage, _ := stage.(map[string]interface{})
+
+		if nestedPipelineID, isString := stage["pipeline"].(string); isString {
+			stage["pipeline"] = IDToPipelineMap[nestedPipelineID]
+
+			if nestedPipeline, isMap := stage["pipeline"].(map[string]interface{}); isMap {
+				formatCurrentPipeline(d, IDToPipelineMap, nestedPipeline)
 			}
 		}
 	}
 }
 
-// cleanKeys Clean some spinnaker generated added fields
+// cleanKeys Clean the spinnaker generated added fields (and the ownership stamped by shore), see `diff.DefaultIgnorePaths`
 func cleanKeys(pipeline map[string]interface{}) {
-	keysToDelete := [5]string{"id", "index", "lastModifiedBy", "updateTs", "schema"}
-	for _, key := range keysToDelete {
+	for _, key := range diff.DefaultIgnorePaths {
 		delete(pipeline, key)
 	}
 }
 
-// getDesiredPipeline Returns the desired pipeline configuration as string and map[string]interface{}
-func getDesiredPipeline(d *Dependencies, projectPath string, renderArgs string, renderType renderer.RenderType) ([]byte, map[string]interface{}) {
+// getDesiredPipelines Returns the desired pipelines configuration as a list of map[string]interface{}
+func getDesiredPipelines(d *Dependencies, projectPath string, renderArgs string, renderType renderer.RenderType) ([]map[string]interface{}, error) {
 	desiredPipelineString, err := d.Renderer.Render(projectPath, renderArgs, renderType)
 
 	if err != nil {
 		d.Logger.Error("Renderer.Render returned an error ", err)
-		return nil, nil
+		return nil, &DiffRenderErr{Err: err}
 	}
 
-	var desiredPipelineInterface map[string]interface{}
-	var json = jsoniter.ConfigCompatibleWithStandardLibrary
-	err = json.UnmarshalFromString(desiredPipelineString, &desiredPipelineInterface)
+	desiredPipelines, _, err := backend.ParsePipelines(desiredPipelineString)
 
 	if err != nil {
-		d.Logger.Error("json.UnmarshalFromString Could not unmarshell ", err)
-		return []byte(desiredPipelineString), nil
+		d.Logger.Error("backend.ParsePipelines Could not parse the rendered pipelines ", err)
+		return nil, &DiffRenderErr{Err: err}
 	}
 
-	return []byte(desiredPipelineString), desiredPipelineInterface
+	return desiredPipelines, nil
 }
 
-// getCurrentPipeline Returns the current pipeline configuration as string and map[string]interface{}
-func getCurrentPipeline(d *Dependencies, args map[string]interface{},
-	IDToPipelineMap map[string]interface{}) ([]byte, map[string]interface{}) {
+// getAgainstPipeline Returns the pipeline of the source with the application & name as string, `nil` when there is none
+func getAgainstPipeline(against *DiffSource, application string, pipeline string) ([]byte, error) {
+	againstPipeline := against.find(application, pipeline)
 
-	application := args["application"].(string)
-	pipeline := args["pipeline"].(string)
-	currentPipelineInterface, _, err := d.Backend.GetPipeline(application, pipeline)
+	if againstPipeline == nil {
+		return nil, nil
+	}
+
+	againstPipeline = copyPipeline(againstPipeline)
+	cleanKeys(againstPipeline)
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	return json.Marshal(againstPipeline)
+}
+
+// getBackendPipeline Returns the current configuration of a pipeline, `nil` when the pipeline doesn't exist
+func getBackendPipeline(d *Dependencies, application string, pipeline string) (map[string]interface{}, error) {
+	currentPipeline, res, err := d.Backend.GetPipeline(application, pipeline)
 
 	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
 		d.Logger.Error("Backend.GetPipeline returned an error ", err)
+		return nil, &DiffPipelineErr{Application: application, Pipeline: pipeline, Err: err}
+	}
+
+	if len(currentPipeline) == 0 {
 		return nil, nil
 	}
 
+	return currentPipeline, nil
+}
+
+// getCurrentPipeline Returns the current pipeline configuration (or a previous revision of it) as string, `nil` when the pipeline doesn't exist
+//
+// The nested pipelines of the desired pipeline are resolved the same way (see `fillPipelineMap`).
+func getCurrentPipeline(d *Dependencies, application string, pipeline string,
+	desiredPipeline map[string]interface{}, revision int) ([]byte, error) {
+
+	IDToPipelineMap := make(map[string]interface{})
+
+	if err := fillPipelineMap(d, IDToPipelineMap, desiredPipeline); err != nil {
+		return nil, err
+	}
+
+	var currentPipelineInterface map[string]interface{}
+
+	if revision != 0 {
+		pipelineRevision, err := GetPipelineRevision(d, application, pipeline, revision)
+
+		if err != nil {
+			return nil, err
+		}
+
+		currentPipelineInterface = pipelineRevision.Pipeline
+
+		if err := fillPipelineMapRevisions(d, IDToPipelineMap, pipelineRevision.Timestamp); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		currentPipelineInterface, err = getBackendPipeline(d, application, pipeline)
+
+		if err != nil || currentPipelineInterface == nil {
+			return nil, err
+		}
+	}
+
 	formatCurrentPipeline(d, IDToPipelineMap, currentPipelineInterface)
 
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
-	currentPipelineString, err := json.Marshal(currentPipelineInterface)
 
-	if err != nil {
-		d.Logger.Error("Diff returned an error ", err)
-		return nil, currentPipelineInterface
+	return json.Marshal(currentPipelineInterface)
+}
+
+// fillPipelineMapRevisions Replace the nested pipelines of the IDToPipelineMap with the revisions that were current at `timestamp`
+func fillPipelineMapRevisions(d *Dependencies, IDToPipelineMap map[string]interface{}, timestamp time.Time) error {
+	for id, nestedPipeline := range IDToPipelineMap {
+		nestedPipelineObject, isMap := nestedPipeline.(map[string]interface{})
+
+		if !isMap {
+			continue
+		}
+
+		application, _ := nestedPipelineObject["application"].(string)
+		name, _ := nestedPipelineObject["name"].(string)
+		nestedRevision, err := getPipelineRevisionAt(d, application, name, timestamp)
+
+		if err != nil {
+			return &DiffPipelineErr{Application: application, Pipeline: name, Err: err}
+		}
+
+		if nestedRevision != nil {
+			IDToPipelineMap[id] = nestedRevision.Pipeline
+		}
 	}
 
-	return currentPipelineString, currentPipelineInterface
+	return nil
 }
 
-// diffAndPrint Creating a diff string from 2 pipeline json strings and print them nicely to stdout
-func diffAndPrint(args map[string]interface{}, currentPipelineString []byte, desiredPipelineString []byte, skipMatches string) {
+// diffCompareOptions The options comparing the pipelines, validates the output & the ignored paths
+func diffCompareOptions(options DiffOptions) (diff.Options, error) {
+	if options.Output == "" {
+		options.Output = DiffOutputConsole
+	}
 
-	application := args["application"].(string)
-	pipeline := args["pipeline"].(string)
+	if options.Output != DiffOutputConsole {
+		// `diff.Format` validates the format
+		if _, err := diff.Format(nil, options.Output); err != nil {
+			return diff.Options{}, fmt.Errorf("unsupported diff output %q, expected one of: %s, %s", options.Output, DiffOutputConsole, strings.Join(diff.Formats, ", "))
+		}
+	}
 
-	diffOptions := jsondiff.DefaultConsoleOptions()
-	diffOptions.SkippedObjectProperty = jsondiff.SkippedObjectProperty
+	compareOptions := diff.DefaultOptions()
+	ignore, err := diff.ParsePaths(options.Ignore)
 
-	if skipMatches == "true" {
-		diffOptions.SkipMatches = true
+	if err != nil {
+		return diff.Options{}, err
 	}
 
-	diffType, diffStr := jsondiff.Compare(currentPipelineString, desiredPipelineString, &diffOptions)
+	compareOptions.Ignore = append(compareOptions.Ignore, ignore...)
+
+	return compareOptions, nil
+}
+
+// diffAndPrint Comparing 2 pipeline json strings and print the difference nicely to stdout
+//
+// The `diff.FormatJSONPatch` changes are collected in `patches` instead (see `printPatches`).
+func diffAndPrint(application string, pipeline string, currentPipelineString []byte, desiredPipelineString []byte, options DiffOptions, compareOptions diff.Options, patches map[string][]diff.Change) error {
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	var currentPipeline, desiredPipeline interface{}
+
+	if len(currentPipelineString) > 0 {
+		if err := json.Unmarshal(currentPipelineString, &currentPipeline); err != nil {
+			return err
+		}
+	}
+
+	if len(desiredPipelineString) > 0 {
+		if err := json.Unmarshal(desiredPipelineString, &desiredPipeline); err != nil {
+			return err
+		}
+	}
+
+	currentPipeline = diff.Normalize(currentPipeline, compareOptions)
+	desiredPipeline = diff.Normalize(desiredPipeline, compareOptions)
+	changes := diff.Compare(currentPipeline, desiredPipeline, compareOptions)
+
+	if options.Output == diff.FormatJSONPatch {
+		patches[application+"/"+pipeline] = changes
+		return nil
+	}
 
 	boldUnderline := color.New(color.Bold, color.Underline)
 	bold := color.New(color.Bold)
 
 	boldUnderline.Println("\nShore Difference Output:")
-	bold.Printf("Application: %v\nPipeline: %v\n\n", application, pipeline)
-	if diffType == jsondiff.FullMatch {
+	bold.Printf("Application: %v\nPipeline: %v\n", application, pipeline)
+
+	if options.Revision != 0 {
+		bold.Printf("Revision: %v\n", options.Revision)
+	}
+
+	currentLabel := "current"
+
+	if options.Against != nil {
+		currentLabel = options.Against.Label
+		bold.Printf("Against: %v\n", currentLabel)
+	}
+
+	fmt.Println()
+
+	switch {
+	case len(currentPipelineString) == 0 && options.Against == nil:
+		color.Yellow("The pipeline doesn't exist in application %q yet, it will be created (I.E. by `shore save`)", application)
+		return nil
+	case len(currentPipelineString) == 0:
+		color.Yellow("The pipeline doesn't exist in %s, it is added", currentLabel)
+		return nil
+	case len(desiredPipelineString) == 0:
+		color.Yellow("The pipeline isn't rendered anymore, it is removed (compared to %s)", currentLabel)
+		return nil
+	}
+
+	if len(changes) == 0 {
 		bold.Printf("There Are No Changes in Configuration!\n\n")
 	}
 
-	fmt.Println(diffStr)
+	switch options.Output {
+	case diff.FormatUnified:
+		printUnified(diff.Unified(changes, currentLabel, "desired"))
+	case diff.FormatSummary:
+		fmt.Print(diff.Summarize(changes).String())
+	default:
+		// Arrays compared by key are aligned, so the console (positional) comparison matches the elements by key.
+		currentPipelineString, _ = json.Marshal(diff.Align(currentPipeline, desiredPipeline, compareOptions))
+		desiredPipelineString, _ = json.Marshal(desiredPipeline)
+
+		diffOptions := jsondiff.DefaultConsoleOptions()
+		diffOptions.SkippedObjectProperty = jsondiff.SkippedObjectProperty
+		diffOptions.SkipMatches = options.SkipMatches
+
+		_, diffStr := jsondiff.Compare(currentPipelineString, desiredPipelineString, &diffOptions)
+		fmt.Println(diffStr)
+	}
+
+	return nil
+}
+
+// printUnified Print a unified diff, colored by line
+func printUnified(unified string) {
+	for _, line := range strings.Split(strings.TrimSuffix(unified, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			color.Cyan(line)
+		case strings.HasPrefix(line, "-"):
+			color.Red(line)
+		case strings.HasPrefix(line, "+"):
+			color.Green(line)
+		default:
+			fmt.Println(line)
+		}
+	}
 }
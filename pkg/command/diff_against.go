@@ -0,0 +1,191 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/spf13/afero"
+)
+
+// DiffSource - Pipelines the rendered pipelines are compared with, instead of the backend.
+type DiffSource struct {
+	// Label - Describes the source in the output (I.E. `profile prod`).
+	Label     string
+	Pipelines []map[string]interface{}
+}
+
+// find - The pipeline of the source with the application & name, `nil` when there is none.
+func (s *DiffSource) find(application, name string) map[string]interface{} {
+	for _, pipeline := range s.Pipelines {
+		if pipeline["application"] == application && pipeline["name"] == name {
+			return pipeline
+		}
+	}
+
+	return nil
+}
+
+// DiffAgainstProfile - The pipelines rendered with another profile (and the same render values & flags).
+func DiffAgainstProfile(d *Dependencies, profile string, renderVals string, renderFlags RenderFlags) (*DiffSource, error) {
+	pipelines, err := renderProfilePipelines(d, profile, renderVals, renderFlags)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the profile %q: %w", profile, err)
+	}
+
+	return &DiffSource{Label: "profile " + profile, Pipelines: pipelines}, nil
+}
+
+// DiffAgainstFile - The pipelines of a JSON file, a pipeline or a set of pipelines (I.E. a saved `shore render` output).
+//
+// Paths that aren't absolute are relative to the project.
+func DiffAgainstFile(d *Dependencies, fileName string) (*DiffSource, error) {
+	filePath, err := projectRelativePath(d, fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(d.Project.FS, filePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, _, err := backend.ParsePipelines(string(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the pipelines of %q: %w", fileName, err)
+	}
+
+	return &DiffSource{Label: "file " + fileName, Pipelines: pipelines}, nil
+}
+
+// DiffAgainstGitRef - The pipelines rendered from a git ref (I.E. `main`), with a profile & the render values & flags.
+//
+// The project is checked out in a temporary worktree, which is removed once rendered.
+// The shared libraries of the ref are installed when the renderer manages dependencies.
+func DiffAgainstGitRef(d *Dependencies, ref string, profile string, renderVals string, renderFlags RenderFlags) (*DiffSource, error) {
+	projectPath, err := d.Project.GetProjectPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectPath, err = filepath.Abs(projectPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projectPath, err = filepath.EvalSymlinks(projectPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryPath, err := git(projectPath, "rev-parse", "--show-toplevel")
+
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryPath, err = filepath.EvalSymlinks(repositoryPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relativePath, err := filepath.Rel(repositoryPath, projectPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	worktreePath, err := os.MkdirTemp("", "shore-diff-")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(worktreePath)
+
+	d.Logger.Debug("Checking out ", ref, " in ", worktreePath)
+
+	if _, err := git(repositoryPath, "worktree", "add", "--detach", worktreePath, ref); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if _, err := git(repositoryPath, "worktree", "remove", "--force", worktreePath); err != nil {
+			d.Logger.Warnf("Failed to remove the git worktree %q: %v", worktreePath, err)
+		}
+	}()
+
+	refDependencies := *d
+	refDependencies.Project = &project.Project{FS: d.Project.FS, Log: d.Project.Log, Path: filepath.Join(worktreePath, relativePath)}
+
+	if err := installRefDependencies(&refDependencies); err != nil {
+		return nil, fmt.Errorf("failed to install the shared libraries of %q: %w", ref, err)
+	}
+
+	pipelines, err := renderProfilePipelines(&refDependencies, profile, renderVals, renderFlags)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the git ref %q: %w", ref, err)
+	}
+
+	return &DiffSource{Label: "git ref " + ref, Pipelines: pipelines}, nil
+}
+
+// installRefDependencies - Installs the shared libraries of a checked out project at their locked versions.
+func installRefDependencies(d *Dependencies) error {
+	dependencyManager, ok := d.Renderer.(renderer.DependencyManager)
+
+	if !ok {
+		return nil
+	}
+
+	projectPath, err := d.Project.GetProjectPath()
+
+	if err != nil {
+		return err
+	}
+
+	dependencies, err := dependencyManager.ListDependencies(projectPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	return dependencyManager.InstallDependencies(projectPath)
+}
+
+// git - Runs a git command in a directory, returns its trimmed output.
+func git(directory string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = directory
+
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
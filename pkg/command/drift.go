@@ -0,0 +1,278 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Autodesk/shore/pkg/config"
+	"github.com/Autodesk/shore/pkg/diff"
+	"github.com/fatih/color"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+)
+
+// DriftExitCode - The exit code of `shore drift` when pipelines drifted, failures exit with 1.
+const DriftExitCode = 2
+
+// The drift status of a pipeline.
+const (
+	DriftStatusInSync  = "in-sync"
+	DriftStatusDrifted = "drifted"
+	DriftStatusMissing = "missing"
+)
+
+// ExitCoder - An error setting the exit code of the CLI.
+type ExitCoder interface {
+	error
+	ExitCode() int
+}
+
+// DriftErr - Returned when pipelines drifted from the project, exits with `DriftExitCode`.
+type DriftErr struct {
+	Drifted int
+}
+
+func (e *DriftErr) Error() string {
+	return fmt.Sprintf("%d pipelines drifted from the project", e.Drifted)
+}
+
+// ExitCode - See `ExitCoder`.
+func (e *DriftErr) ExitCode() int {
+	return DriftExitCode
+}
+
+// PipelineDrift - How a pipeline of the backend differs from the rendered pipeline.
+type PipelineDrift struct {
+	Application string `json:"application"`
+	Name        string `json:"name"`
+	// Status - `in-sync`, `drifted` or `missing`.
+	Status string `json:"status"`
+	// Paths - The paths that drifted (I.E. `stages[refId=2].waitTime`), see `diff.Change`.
+	Paths []string `json:"paths,omitempty"`
+	// LastModifiedBy & UpdateTs - Who changed the pipeline last, and when (RFC3339).
+	LastModifiedBy string `json:"lastModifiedBy,omitempty"`
+	UpdateTs       string `json:"updateTs,omitempty"`
+}
+
+// DriftReport - The drift of all the pipelines of the rendered tree.
+type DriftReport struct {
+	Drifted   int             `json:"drifted"`
+	Pipelines []PipelineDrift `json:"pipelines"`
+}
+
+// NewDriftCommand - Compares every pipeline of the rendered tree with the backend.
+func NewDriftCommand(d *Dependencies) *cobra.Command {
+	var renderVals string
+	var renderFlags RenderFlags
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect pipelines that drifted from the project",
+		Long: fmt.Sprintf(`Compare every pipeline of the rendered tree (nested pipelines included) with the backend.
+Lists the paths that drifted and who changed the pipeline last, exits with %d when pipelines drifted (I.E. for scheduled CI jobs).`, DriftExitCode),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output %q, expected one of: table, json", output)
+			}
+
+			pipelines, err := renderProjectPipelines(d, cmd, renderVals, renderFlags)
+
+			if err != nil {
+				return err
+			}
+
+			ignore, err := config.LoadDiffIgnorePaths(d.Project)
+
+			if err != nil {
+				return err
+			}
+
+			// Compared like `shore diff` compares them.
+			compareOptions, err := diffCompareOptions(DiffOptions{Ignore: ignore})
+
+			if err != nil {
+				return err
+			}
+
+			report, err := DetectDrift(d, pipelines, compareOptions)
+
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				data, err := jsoniter.MarshalIndent(report, "", "  ")
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			case "table":
+				writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(writer, "APPLICATION\tPIPELINE\tSTATUS\tPATHS\tLAST MODIFIED BY\tUPDATED")
+
+				for _, drift := range report.Pipelines {
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", drift.Application, drift.Name, drift.Status,
+						orDash(strings.Join(drift.Paths, ", ")), orDash(drift.LastModifiedBy), orDash(drift.UpdateTs))
+				}
+
+				if err := writer.Flush(); err != nil {
+					return err
+				}
+
+				if report.Drifted == 0 {
+					color.Green("No drift detected, %d pipelines are in sync", len(report.Pipelines))
+				}
+			}
+
+			if report.Drifted > 0 {
+				return &DriftErr{Drifted: report.Drifted}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "The output format (one of: table, json).")
+
+	renderFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// DetectDrift - Compares the rendered pipelines (and their nested pipelines) with the backend, see `diff.Compare`.
+//
+// The pipelines are compared the way they are saved, nested pipelines & pipelines referenced by ID are compared by name.
+func DetectDrift(d *Dependencies, pipelines []map[string]interface{}, options diff.Options) (*DriftReport, error) {
+	report := &DriftReport{Pipelines: []PipelineDrift{}}
+	seen := make(map[string]bool)
+
+	for _, pipeline := range pipelines {
+		for _, desired := range flattenPipeline(pipeline) {
+			application, _ := desired["application"].(string)
+			name, _ := desired["name"].(string)
+
+			key := application + "/" + name
+
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			drift, err := pipelineDrift(d, desired, options)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if drift.Status != DriftStatusInSync {
+				report.Drifted++
+			}
+
+			report.Pipelines = append(report.Pipelines, *drift)
+		}
+	}
+
+	return report, nil
+}
+
+func pipelineDrift(d *Dependencies, desired map[string]interface{}, options diff.Options) (*PipelineDrift, error) {
+	application, _ := desired["application"].(string)
+	name, _ := desired["name"].(string)
+	drift := &PipelineDrift{Application: application, Name: name}
+
+	// Only a pipeline that doesn't exist (404) is missing, other errors (I.E. a Gate outage) fail the run.
+	current, err := getBackendPipeline(d, application, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		drift.Status = DriftStatusMissing
+		return drift, nil
+	}
+
+	drift.LastModifiedBy, _ = current["lastModifiedBy"].(string)
+
+	if _, exists := current["updateTs"]; exists {
+		drift.UpdateTs = formatRevisionTimestamp(revisionTimestamp(current))
+	}
+
+	cleanKeys(current)
+
+	if err := replacePipelineIDsWithNames(d, current); err != nil {
+		return nil, err
+	}
+
+	for _, change := range diff.Compare(current, desired, options) {
+		drift.Paths = appendUniquePath(drift.Paths, change.Path.String())
+	}
+
+	drift.Status = DriftStatusInSync
+
+	if len(drift.Paths) > 0 {
+		drift.Status = DriftStatusDrifted
+	}
+
+	return drift, nil
+}
+
+// flattenPipeline - The pipelines of a rendered tree, nested pipelines first.
+//
+// Nested pipelines (`NestedPipelineStage`) are replaced with their names, like the saved pipelines reference them (by ID).
+func flattenPipeline(pipeline map[string]interface{}) []map[string]interface{} {
+	stages, hasStages := pipeline["stages"].([]interface{})
+
+	if !hasStages {
+		return []map[string]interface{}{pipeline}
+	}
+
+	var pipelines []map[string]interface{}
+	flattenedStages := make([]interface{}, 0, len(stages))
+
+	for _, stage := range stages {
+		stageMap, _ := stage.(map[string]interface{})
+		nested, isNested := stageMap["pipeline"].(map[string]interface{})
+
+		if !isNested || stageMap["type"] != "pipeline" {
+			flattenedStages = append(flattenedStages, stage)
+			continue
+		}
+
+		pipelines = append(pipelines, flattenPipeline(nested)...)
+
+		flattenedStage := copyPipeline(stageMap)
+		flattenedStage["pipeline"] = nested["name"]
+		flattenedStages = append(flattenedStages, flattenedStage)
+	}
+
+	flattened := copyPipeline(pipeline)
+	flattened["stages"] = flattenedStages
+
+	return append(pipelines, flattened)
+}
+
+func appendUniquePath(paths []string, path string) []string {
+	for _, p := range paths {
+		if p == path {
+			return paths
+		}
+	}
+
+	return append(paths, path)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
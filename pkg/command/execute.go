@@ -25,7 +25,7 @@ func NewExecCommand(d *Dependencies, configPath string) *cobra.Command {
 		Short: "Executes the pipeline",
 		Long:  "Executes the selected pipeline",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settingsBytes, err := config.LoadConfig(d.Project, withPayload, configPath)
+			settingsBytes, err := config.LoadProfileConfig(d.Project, withPayload, configPath, ProfileName(cmd))
 
 			if err != nil && !errors.Is(err, os.ErrNotExist) {
 				return err
@@ -76,7 +76,7 @@ func NewExecCommand(d *Dependencies, configPath string) *cobra.Command {
 	cmd.Flags().BoolVarP(&withWait, "wait", "w", false, "Wait for the pipeline to finish execution")
 	cmd.Flags().BoolVarP(&withSilent, "silent", "s", false, "Do not print JSON response to STDOUT")
 	cmd.Flags().IntVarP(&waitTimeout, "timeout", "t", 60, "how long to wait (Seconds) for the pipeline to finish in Seconds. Yes Seconds.")
-	cmd.Flags().StringVarP(&withPayload, "payload", "p", "", "A JSON payload string. If not provided the exec.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&withPayload, "payload", "p", "", "A JSON payload string (or a file path), deep merged on top of the exec.[json/yml/yaml] file and the profile overrides.")
 	cmd.Flags().BoolVarP(&stringifyNonScalars, "stringify", "y", true, "Stringifies the non scalar parameters to SpinCli")
 
 	return cmd
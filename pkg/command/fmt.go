@@ -0,0 +1,62 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// NewFmtCommand - Using a Project & Renderer, checks (or fixes) the formatting of the project's code.
+// Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
+func NewFmtCommand(d *Dependencies) *cobra.Command {
+	var write bool
+
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Check the formatting of the project's code",
+		Long: `Check the formatting of the project's code (shared libraries are skipped).
+Exits with a non-zero code when files are not formatted, use "--write" to format the files instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter, ok := d.Renderer.(renderer.Formatter)
+
+			if !ok {
+				return fmt.Errorf("the renderer does not support formatting")
+			}
+
+			projectPath, err := d.Project.GetProjectPath()
+
+			if err != nil {
+				return err
+			}
+
+			d.Logger.Info("calling Renderer.Format with projectPath ", projectPath)
+			files, err := formatter.Format(projectPath, write)
+
+			if err != nil {
+				d.Logger.Error("Renderer.Format returned an error ", err)
+				return err
+			}
+
+			if write {
+				for _, file := range files {
+					color.Green("formatted %s", file)
+				}
+
+				return nil
+			}
+
+			if len(files) > 0 {
+				return fmt.Errorf("the following files are not formatted (run `shore fmt --write`):\n\t%s", strings.Join(files, "\n\t"))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&write, "write", "w", false, "Format the files instead of checking them.")
+
+	return cmd
+}
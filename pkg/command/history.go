@@ -0,0 +1,318 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/Autodesk/shore/pkg/config"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+)
+
+// DefaultHistoryLimit - The number of revisions listed by `shore history`.
+const DefaultHistoryLimit = 20
+
+// nestedHistoryLimit - The number of revisions searched for the revision of a nested pipeline.
+// Nested pipelines are saved with their parent, they usually have as many revisions.
+const nestedHistoryLimit = 100
+
+// PipelineRevision - A saved revision of a pipeline configuration.
+type PipelineRevision struct {
+	// Number - 0 is the current configuration, 1 the previous one, etc.
+	Number    int
+	Timestamp time.Time
+	// User - The user that saved the revision (`lastModifiedBy`).
+	User     string
+	Pipeline map[string]interface{}
+}
+
+// NewHistoryCommand - Lists the revisions of the rendered pipelines.
+func NewHistoryCommand(d *Dependencies) *cobra.Command {
+	var renderVals string
+	var renderFlags RenderFlags
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the revisions of the pipeline",
+		Long: `List the saved revisions of the rendered pipelines, newest first.
+Revision 0 is the current configuration, use "shore diff --revision N" & "shore rollback --revision N" with the listed revisions.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelines, err := renderProjectPipelines(d, cmd, renderVals, renderFlags)
+
+			if err != nil {
+				return err
+			}
+
+			for _, pipeline := range pipelines {
+				application, _ := pipeline["application"].(string)
+				name, _ := pipeline["name"].(string)
+				revisions, err := GetPipelineRevisions(d, application, name, limit)
+
+				if err != nil {
+					return err
+				}
+
+				bold := color.New(color.Bold)
+				bold.Fprintf(cmd.OutOrStdout(), "\nApplication: %v\nPipeline: %v\n\n", application, name)
+
+				if len(revisions) == 0 {
+					color.New(color.FgYellow).Fprintln(cmd.OutOrStdout(), "No revisions found, the pipeline wasn't saved yet")
+					continue
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "REVISION\tTIMESTAMP\tUSER")
+
+				for _, revision := range revisions {
+					fmt.Fprintf(w, "%d\t%s\t%s\n", revision.Number, formatRevisionTimestamp(revision.Timestamp), revision.User)
+				}
+
+				w.Flush()
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+	cmd.Flags().IntVar(&limit, "limit", DefaultHistoryLimit, "The number of revisions to list.")
+
+	renderFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// NewRollbackCommand - Restores a revision of the rendered pipelines and their nested pipelines.
+func NewRollbackCommand(d *Dependencies) *cobra.Command {
+	var renderVals string
+	var renderFlags RenderFlags
+	var revision int
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore a previous revision of the pipeline",
+		Long: `Save a previous revision (listed by "shore history") of the rendered pipelines.
+The nested pipelines are restored to the revisions that were current when the pipeline revision was saved.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if revision < 1 {
+				return fmt.Errorf("the revision must be 1 or more, revision 0 is the current configuration")
+			}
+
+			pipelines, err := renderProjectPipelines(d, cmd, renderVals, renderFlags)
+
+			if err != nil {
+				return err
+			}
+
+			var revisions []map[string]interface{}
+
+			for _, pipeline := range pipelines {
+				pipelineRevisions, err := RollbackPipelines(d, pipeline, revision)
+
+				if err != nil {
+					return err
+				}
+
+				revisions = append(revisions, pipelineRevisions...)
+			}
+
+			revisionsJSON, err := jsoniter.MarshalToString(revisions)
+
+			if err != nil {
+				return err
+			}
+
+			s := spinner.New(spinner.CharSets[9], 50*time.Millisecond)
+			s.Writer = color.Error
+			s.Prefix = "Restoring spinnaker pipelines, please wait... "
+			s.Start()
+			res, err := d.Backend.SavePipeline(revisionsJSON, backend.SaveOptions{})
+			s.Stop()
+
+			if err != nil {
+				d.Logger.Warnf("Save pipeline returned an error: %v", err)
+				return err
+			}
+
+			d.Logger.Info("Backend.SavePipeline returned")
+			fmt.Println(res)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+	cmd.Flags().IntVar(&revision, "revision", 0, "The revision to restore (see \"shore history\").")
+	cmd.MarkFlagRequired("revision")
+
+	renderFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// renderProjectPipelines - Renders the project's main file, the pipelines of a rendered set are returned separately.
+func renderProjectPipelines(d *Dependencies, cmd *cobra.Command, renderVals string, renderFlags RenderFlags) ([]map[string]interface{}, error) {
+	return renderProfilePipelines(d, ProfileName(cmd), renderVals, renderFlags)
+}
+
+// renderProfilePipelines - Renders the project's main file with a profile, see `renderProjectPipelines`.
+func renderProfilePipelines(d *Dependencies, profile string, renderVals string, renderFlags RenderFlags) ([]map[string]interface{}, error) {
+	settingsBytes, err := config.LoadProfileConfig(d.Project, renderVals, "render", profile)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineJSON, err := Render(d, settingsBytes, renderer.MainFileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, _, err := backend.ParsePipelines(pipelineJSON)
+
+	return pipelines, err
+}
+
+// GetPipelineRevisions - The (up to `limit`) latest revisions of a pipeline, newest first.
+func GetPipelineRevisions(d *Dependencies, application, pipelineName string, limit int) ([]PipelineRevision, error) {
+	history, err := d.Backend.GetPipelineHistory(application, pipelineName, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]PipelineRevision, 0, len(history))
+
+	for i, pipeline := range history {
+		user, _ := pipeline["lastModifiedBy"].(string)
+
+		revisions = append(revisions, PipelineRevision{
+			Number:    i,
+			Timestamp: revisionTimestamp(pipeline),
+			User:      user,
+			Pipeline:  pipeline,
+		})
+	}
+
+	return revisions, nil
+}
+
+// GetPipelineRevision - A revision of a pipeline, see `PipelineRevision.Number`.
+func GetPipelineRevision(d *Dependencies, application, pipelineName string, revision int) (*PipelineRevision, error) {
+	revisions, err := GetPipelineRevisions(d, application, pipelineName, revision+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("pipeline %q doesn't exist in application %q", pipelineName, application)
+	}
+
+	if revision >= len(revisions) {
+		return nil, fmt.Errorf("pipeline %q of application %q has no revision %d, its oldest revision is %d", pipelineName, application, revision, len(revisions)-1)
+	}
+
+	return &revisions[revision], nil
+}
+
+// getPipelineRevisionAt - The revision of a pipeline that was current at `timestamp`, `nil` when there is none.
+func getPipelineRevisionAt(d *Dependencies, application, pipelineName string, timestamp time.Time) (*PipelineRevision, error) {
+	revisions, err := GetPipelineRevisions(d, application, pipelineName, nestedHistoryLimit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range revisions {
+		if !revisions[i].Timestamp.After(timestamp) {
+			return &revisions[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RollbackPipelines - The configurations restoring a revision of a rendered pipeline, its nested pipelines first.
+//
+// The nested pipelines (of the rendered pipeline) are restored to the revisions that were current when the
+// pipeline revision was saved, nested pipelines that didn't exist then are left as-is.
+func RollbackPipelines(d *Dependencies, pipeline map[string]interface{}, revision int) ([]map[string]interface{}, error) {
+	application, _ := pipeline["application"].(string)
+	name, _ := pipeline["name"].(string)
+	parentRevision, err := GetPipelineRevision(d, application, name, revision)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineJSON, err := jsoniter.MarshalToString(pipeline)
+
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := d.Backend.GetPipelinesNamesByApplication(pipelineJSON)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pipelines []map[string]interface{}
+
+	for _, nested := range applications {
+		for _, nestedName := range nested.Names {
+			if nested.Application == application && nestedName == name {
+				continue
+			}
+
+			nestedRevision, err := getPipelineRevisionAt(d, nested.Application, nestedName, parentRevision.Timestamp)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if nestedRevision == nil {
+				d.Logger.Warnf("Pipeline %q of application %q has no revision older than %s, it isn't rolled back", nestedName, nested.Application, formatRevisionTimestamp(parentRevision.Timestamp))
+				continue
+			}
+
+			pipelines = append(pipelines, nestedRevision.Pipeline)
+		}
+	}
+
+	return append(pipelines, parentRevision.Pipeline), nil
+}
+
+// revisionTimestamp - The time a revision was saved (`updateTs`, in milliseconds since epoch as a string or a number).
+func revisionTimestamp(pipeline map[string]interface{}) time.Time {
+	var milliseconds int64
+
+	switch updateTs := pipeline["updateTs"].(type) {
+	case string:
+		milliseconds, _ = strconv.ParseInt(updateTs, 10, 64)
+	case float64:
+		milliseconds = int64(updateTs)
+	}
+
+	return time.UnixMilli(milliseconds).UTC()
+}
+
+func formatRevisionTimestamp(timestamp time.Time) string {
+	return timestamp.Format(time.RFC3339)
+}
@@ -0,0 +1,223 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultImportLibrary - The shared library the generated code of imported pipelines uses.
+const DefaultImportLibrary = "https://github.com/Autodesk/shore/jsonnet/libs/spin-lib-jsonnet"
+
+// NewImportCommand - Creates a shore project from an existing pipeline of the backend.
+func NewImportCommand(d *Dependencies) *cobra.Command {
+	var application string
+	var pipelineName string
+	var libs []string
+	var force bool
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create a project from an existing pipeline",
+		Long: `Create a shore project (in the current directory) from an existing pipeline.
+The generated code renders the pipeline, the render, exec & E2E configurations are created for it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			generator, ok := d.Renderer.(renderer.Generator)
+
+			if !ok {
+				return fmt.Errorf("the renderer doesn't support importing pipelines")
+			}
+
+			pipeline, err := ImportPipeline(d, application, pipelineName)
+
+			if err != nil {
+				return err
+			}
+
+			files, err := generator.Generate(pipeline)
+
+			if err != nil {
+				return err
+			}
+
+			configFiles, err := importConfigFiles(application, pipelineName, pipeline)
+
+			if err != nil {
+				return err
+			}
+
+			for fileName, content := range configFiles {
+				files[fileName] = content
+			}
+
+			projectName := name
+
+			if projectName == "" {
+				if projectName, err = d.Project.GetProjectName(); err != nil {
+					return err
+				}
+			}
+
+			shoreInit := project.NewShoreProjectInit(projectName, project.Renderers[0], project.Backends[0], libs)
+			shoreInit.Template = project.ImportTemplate
+			shoreInit.Force = force
+			shoreInit.Files = files
+
+			pInit := &project.ProjectInitialize{
+				Log:     d.Logger,
+				Project: *d.Project,
+			}
+
+			if err := pInit.Init(shoreInit); err != nil {
+				return err
+			}
+
+			color.Green("Pipeline %q of application %q has been imported successfully!", pipelineName, application)
+			color.Cyan("Try running `shore deps install` and `shore diff`")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&application, "application", "", "The application of the pipeline to import.")
+	cmd.Flags().StringVar(&pipelineName, "pipeline", "", "The name of the pipeline to import.")
+	cmd.Flags().StringArrayVar(&libs, "lib", []string{DefaultImportLibrary}, "A shared library to add to the project. May be repeated.")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files.")
+	cmd.Flags().StringVar(&name, "name", "", "The project name, written to shore.yml (defaults to the project directory name).")
+	cmd.MarkFlagRequired("application")
+	cmd.MarkFlagRequired("pipeline")
+
+	return cmd
+}
+
+// ImportPipeline - Fetches a pipeline from the backend, ready to be generated as code.
+//
+// The Spinnaker generated fields are removed (see `cleanKeys`), the pipeline IDs referenced by pipeline stages
+// & triggers are replaced with the pipelines names.
+func ImportPipeline(d *Dependencies, application, pipelineName string) (map[string]interface{}, error) {
+	pipeline, _, err := d.Backend.GetPipeline(application, pipelineName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pipeline) == 0 {
+		return nil, fmt.Errorf("pipeline %q doesn't exist in application %q", pipelineName, application)
+	}
+
+	cleanKeys(pipeline)
+
+	if err := replacePipelineIDsWithNames(d, pipeline); err != nil {
+		return nil, err
+	}
+
+	return pipeline, nil
+}
+
+// replacePipelineIDsWithNames - Replaces the IDs of the pipelines referenced by pipeline stages & triggers with their names.
+//
+// Unknown IDs (I.E. deleted pipelines) and SpEL expressions are kept as-is.
+func replacePipelineIDsWithNames(d *Dependencies, pipeline map[string]interface{}) error {
+	namesByApplication := make(map[string]map[string]string)
+
+	replace := func(reference map[string]interface{}) error {
+		application, _ := reference["application"].(string)
+		id, _ := reference["pipeline"].(string)
+
+		if reference["type"] != "pipeline" || application == "" || id == "" || strings.Contains(id, "${") {
+			return nil
+		}
+
+		names, fetched := namesByApplication[application]
+
+		if !fetched {
+			pipelines, err := d.Backend.ListPipelines(application)
+
+			if err != nil {
+				return err
+			}
+
+			names = make(map[string]string, len(pipelines))
+
+			for _, p := range pipelines {
+				pipelineID, _ := p["id"].(string)
+				names[pipelineID], _ = p["name"].(string)
+			}
+
+			namesByApplication[application] = names
+		}
+
+		if name, exists := names[id]; exists && name != "" {
+			reference["pipeline"] = name
+			return nil
+		}
+
+		d.Logger.Warnf("Pipeline %q referenced from application %q was not found, the reference is kept as-is", id, application)
+		return nil
+	}
+
+	for _, key := range []string{"triggers", "stages"} {
+		references, _ := pipeline[key].([]interface{})
+
+		for _, reference := range references {
+			if referenceMap, isMap := reference.(map[string]interface{}); isMap {
+				if err := replace(referenceMap); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// importConfigFiles - The render, exec & E2E configurations of an imported pipeline.
+//
+// The parameters of the executions are the parameters of the pipeline, set to their default values.
+func importConfigFiles(application, pipelineName string, pipeline map[string]interface{}) (map[string]string, error) {
+	target := yaml.MapSlice{
+		{Key: "application", Value: application},
+		{Key: "pipeline", Value: pipelineName},
+	}
+
+	parameters := yaml.MapSlice{}
+	parameterConfig, _ := pipeline["parameterConfig"].([]interface{})
+
+	for _, config := range parameterConfig {
+		if parameter, isMap := config.(map[string]interface{}); isMap {
+			defaultValue, _ := parameter["default"].(string)
+			parameters = append(parameters, yaml.MapItem{Key: parameter["name"], Value: defaultValue})
+		}
+	}
+
+	exec := append(yaml.MapSlice{}, target...)
+	test := yaml.MapSlice{}
+
+	if len(parameters) > 0 {
+		exec = append(exec, yaml.MapItem{Key: "parameters", Value: parameters})
+		test = append(test, yaml.MapItem{Key: "execution_args", Value: yaml.MapSlice{{Key: "parameters", Value: parameters}}})
+	}
+
+	test = append(test, yaml.MapItem{Key: "assertions", Value: yaml.MapSlice{}})
+	e2e := append(append(yaml.MapSlice{}, target...), yaml.MapItem{Key: "tests", Value: yaml.MapSlice{{Key: "Test Success", Value: test}}})
+
+	files := make(map[string]string)
+
+	for fileName, config := range map[string]yaml.MapSlice{"render.yml": target, "exec.yml": exec, "E2E.yml": e2e} {
+		content, err := yaml.Marshal(config)
+
+		if err != nil {
+			return nil, err
+		}
+
+		files[fileName] = string(content)
+	}
+
+	return files, nil
+}
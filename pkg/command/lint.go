@@ -0,0 +1,48 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/spf13/cobra"
+)
+
+// NewLintCommand - Using a Project & Renderer, lints the project's code.
+// Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
+func NewLintCommand(d *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Lint the project's code",
+		Long: `Lint the project's code (I.E. unused variables), shared libraries are skipped.
+Exits with a non-zero code when issues are found.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			linter, ok := d.Renderer.(renderer.Linter)
+
+			if !ok {
+				return fmt.Errorf("the renderer does not support linting")
+			}
+
+			projectPath, err := d.Project.GetProjectPath()
+
+			if err != nil {
+				return err
+			}
+
+			d.Logger.Info("calling Renderer.Lint with projectPath ", projectPath)
+			issuesFound, err := linter.Lint(projectPath, cmd.OutOrStdout())
+
+			if err != nil {
+				d.Logger.Error("Renderer.Lint returned an error ", err)
+				return err
+			}
+
+			if issuesFound {
+				return fmt.Errorf("lint issues were found")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
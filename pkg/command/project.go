@@ -2,6 +2,8 @@ package command
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Autodesk/shore/pkg/project"
@@ -37,13 +39,18 @@ var projectNamePrompt = promptui.Prompt{
 // Should be setup through some automation of selected plugins
 var rendererPrompt = promptui.Select{
 	Label: "Frontend Renderer",
-	Items: []string{"Jsonnnet"},
+	Items: project.Renderers,
 }
 
 // Should be setup through some automation of selected plugins
 var backendPrompt = promptui.Select{
 	Label: "Pipeline Backend",
-	Items: []string{"Spinnaker"},
+	Items: project.Backends,
+}
+
+var templatePrompt = promptui.Select{
+	Label: "Project Template",
+	Items: project.TemplateNames(),
 }
 
 var addLibsPrompt = promptui.Prompt{
@@ -56,13 +63,29 @@ var libraryPrompt = promptui.Prompt{
 	Label: "Library path (leave empty to continue)",
 }
 
+// projectInitFlags - Values provided on the command line, skipping their prompts.
+type projectInitFlags struct {
+	name     string
+	renderer string
+	backend  string
+	libs     []string
+	template string
+	yes      bool
+	force    bool
+	profiles []string
+}
+
 // NewProjectInitCommand - initialize a `shore` project.
 func NewProjectInitCommand(d *Dependencies) *cobra.Command {
+	var flags projectInitFlags
+
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize a new shore project",
+		Long: `Initialize a new shore project.
+Values that are not provided with flags are prompted for, use "--yes" to use the defaults instead (I.E. in CI).`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			shoreProjectInit, err := getShoreInitValues()
+			shoreProjectInit, err := getShoreInitValues(d, cmd, flags)
 
 			if err != nil {
 				return err
@@ -77,57 +100,135 @@ func NewProjectInitCommand(d *Dependencies) *cobra.Command {
 			s.Suffix = " Setting up the environment, this may take a few moments (depending on Internet traffic!)"
 			s.Start() // Start the spinner
 
-			if err = pInit.Init(shoreProjectInit); err != nil {
+			err = pInit.Init(shoreProjectInit)
+			s.Stop()
+
+			if err != nil {
 				return err
 			}
 
-			s.Stop()
-
 			color.Green("Project %s has been created successfully!", shoreProjectInit.ProjectName())
-			color.Cyan("Try running `shore render`")
+
+			if len(shoreProjectInit.Libraries) > 0 {
+				color.Cyan("Try running `shore deps install` and `shore render`")
+			} else {
+				color.Cyan("Try running `shore render`")
+			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&flags.name, "name", "", "The project name (defaults to the project directory name with --yes).")
+	cmd.Flags().StringVar(&flags.renderer, "renderer", "", fmt.Sprintf("The renderer (one of: %s).", strings.Join(project.Renderers, ", ")))
+	cmd.Flags().StringVar(&flags.backend, "backend", "", fmt.Sprintf("The backend (one of: %s).", strings.Join(project.Backends, ", ")))
+	cmd.Flags().StringArrayVar(&flags.libs, "lib", []string{}, "A shared library to add to the project. May be repeated.")
+	cmd.Flags().StringVar(&flags.template, "template", "", fmt.Sprintf("The project template (one of: %s) or a local template directory (default %q).", strings.Join(project.TemplateNames(), ", "), project.DefaultTemplate))
+	cmd.Flags().BoolVarP(&flags.yes, "yes", "y", false, "Don't prompt, use the defaults for values that are not provided.")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Overwrite existing files.")
+	cmd.Flags().StringArrayVar(&flags.profiles, "add-profile", []string{}, "An additional profile (I.E. an environment) to create in shore.yml, besides the default profile. May be repeated.")
+
 	return cmd
 }
 
-func getShoreInitValues() (project.ShoreProjectInit, error) {
-	projectName, err := projectNamePrompt.Run()
+func getShoreInitValues(d *Dependencies, cmd *cobra.Command, flags projectInitFlags) (project.ShoreProjectInit, error) {
+	projectName, err := getProjectName(d, flags)
 
 	if err != nil {
 		return project.ShoreProjectInit{}, err
 	}
 
-	_, renderer, err := rendererPrompt.Run()
+	renderer, err := getOption("renderer", flags.renderer, project.Renderers, flags.yes, rendererPrompt)
 
 	if err != nil {
 		return project.ShoreProjectInit{}, err
 	}
 
-	_, backend, err := backendPrompt.Run()
+	backend, err := getOption("backend", flags.backend, project.Backends, flags.yes, backendPrompt)
 
 	if err != nil {
 		return project.ShoreProjectInit{}, err
 	}
 
+	template := flags.template
+
+	if template == "" && flags.yes {
+		template = project.DefaultTemplate
+	} else if template == "" {
+		templatePrompt.CursorPos = indexOf(project.TemplateNames(), project.DefaultTemplate)
+
+		if _, template, err = templatePrompt.Run(); err != nil {
+			return project.ShoreProjectInit{}, err
+		}
+	}
+
+	libs := flags.libs
+
+	if !flags.yes && !cmd.Flags().Changed("lib") {
+		if libs, err = promptLibraries(); err != nil {
+			return project.ShoreProjectInit{}, err
+		}
+	}
+
+	shoreInit := project.NewShoreProjectInit(projectName, renderer, backend, libs)
+	shoreInit.Template = template
+	shoreInit.Force = flags.force
+	shoreInit.Profiles = flags.profiles
+
+	return shoreInit, nil
+}
+
+func getProjectName(d *Dependencies, flags projectInitFlags) (string, error) {
+	if flags.name != "" {
+		return flags.name, nil
+	}
+
+	if !flags.yes {
+		return projectNamePrompt.Run()
+	}
+
+	projectPath, err := d.Project.GetProjectPath()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(projectPath), nil
+}
+
+// getOption - Returns the option matching the flag value (case insensitive), the first option with `--yes` or prompts for it.
+func getOption(name, value string, options []string, yes bool, prompt promptui.Select) (string, error) {
+	if value == "" && yes {
+		return options[0], nil
+	}
+
+	if value == "" {
+		_, option, err := prompt.Run()
+		return option, err
+	}
+
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported %s %q, expected one of: %s", name, value, strings.Join(options, ", "))
+}
+
+func promptLibraries() ([]string, error) {
 	// Confirmation prompt returns a string, but we don't care.
 	// Err is used as a bool here for some reason.
 	// TODO: check if this prompt could be customized to return `bool, err`.
-	_, err = addLibsPrompt.Run()
+	_, err := addLibsPrompt.Run()
 
 	var libs []string
 	if err == nil {
 		for {
 			lib, err := libraryPrompt.Run()
 
-			if err != nil {
-				// d.Logger.Error(err)
-			}
-
 			if err == promptui.ErrInterrupt {
-				return project.ShoreProjectInit{}, err
+				return nil, err
 			}
 
 			if lib == "" {
@@ -138,5 +239,15 @@ func getShoreInitValues() (project.ShoreProjectInit, error) {
 		}
 	}
 
-	return project.NewShoreProjectInit(projectName, renderer, backend, libs), nil
+	return libs, nil
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return 0
 }
@@ -0,0 +1,176 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Autodesk/shore/pkg/backend"
+	"github.com/Autodesk/shore/pkg/config"
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+)
+
+// NewPruneCommand - Using a Project, Renderer & Backend, finds (and deletes) the orphaned pipelines of the project.
+//
+// An orphaned pipeline is owned by the project (see `backend.Ownership`) but isn't rendered anymore,
+// I.E. a nested pipeline that was renamed or removed.
+func NewPruneCommand(d *Dependencies) *cobra.Command {
+	var renderVals string
+	var renderFlags RenderFlags
+	var deleteOrphans bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "List (and delete) the orphaned pipelines",
+		Long: `List the pipelines of the rendered applications that were saved by this project (and profile) but are no longer rendered.
+Use "--delete" to delete them. The project must have a "name" in shore.yml.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settingsBytes, err := config.LoadProfileConfig(d.Project, renderVals, "render", ProfileName(cmd))
+
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
+			pipeline, err := Render(d, settingsBytes, renderer.MainFileName)
+
+			if err != nil {
+				return err
+			}
+
+			owner, err := ProjectOwnership(d, renderer.MainFileName, ProfileName(cmd))
+
+			if err != nil {
+				return err
+			}
+
+			orphans, err := FindOrphanedPipelines(d, pipeline, owner)
+
+			if err != nil {
+				return err
+			}
+
+			if len(orphans) == 0 {
+				color.Green("No orphaned pipelines found")
+				return nil
+			}
+
+			for _, application := range orphans {
+				color.Yellow(fmt.Sprintf("Application: %s", application.Application))
+				color.Yellow(fmt.Sprintf("Orphaned pipelines: %s", application.Names))
+			}
+
+			if !deleteOrphans {
+				return nil
+			}
+
+			orphansJSON, err := orphanedPipelinesJSON(orphans)
+
+			if err != nil {
+				return err
+			}
+
+			s := spinner.New(spinner.CharSets[9], 50*time.Millisecond)
+			s.Writer = color.Error
+			s.Prefix = "Deleting orphaned spinnaker pipelines, please wait... "
+			s.Start()
+			res, err := d.Backend.DeletePipeline(orphansJSON)
+			s.Stop()
+
+			if err != nil {
+				d.Logger.Warnf("Delete pipeline returned an error: %v", err)
+				return err
+			}
+
+			d.Logger.Info("Backend.DeletePipeline returned")
+			fmt.Println(res)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+	cmd.Flags().BoolVar(&deleteOrphans, "delete", false, "Delete the orphaned pipelines.")
+
+	renderFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// FindOrphanedPipelines - The pipelines owned by `owner` in the applications of the rendered output,
+// that aren't part of the rendered output, grouped by application.
+//
+// The owner must match exactly (project, source & profile), pipelines saved by other profiles of the project
+// (or stamped by older versions of shore) are never orphaned.
+func FindOrphanedPipelines(d *Dependencies, pipelineJSON string, owner *backend.Ownership) ([]backend.ApplicationPipelines, error) {
+	// Without a name, the project can't tell its pipelines from the pipelines of other projects.
+	if owner == nil || owner.Project == "" {
+		return nil, fmt.Errorf("the project has no \"name\" in shore.yml, set a name unique to the project to prune its pipelines")
+	}
+
+	applications, err := d.Backend.GetPipelinesNamesByApplication(pipelineJSON)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []backend.ApplicationPipelines
+
+	for _, application := range applications {
+		rendered := make(map[string]bool, len(application.Names))
+
+		for _, name := range application.Names {
+			rendered[name] = true
+		}
+
+		pipelines, err := d.Backend.ListPipelines(application.Application)
+
+		if err != nil {
+			return nil, err
+		}
+
+		orphaned := backend.ApplicationPipelines{Application: application.Application}
+
+		for _, pipeline := range pipelines {
+			name, _ := pipeline["name"].(string)
+			pipelineOwner := backend.GetOwnership(pipeline)
+
+			if rendered[name] || pipelineOwner == nil || *pipelineOwner != *owner {
+				continue
+			}
+
+			orphaned.Names = append(orphaned.Names, name)
+		}
+
+		if len(orphaned.Names) > 0 {
+			orphans = append(orphans, orphaned)
+		}
+	}
+
+	return orphans, nil
+}
+
+// orphanedPipelinesJSON - A set of pipelines (`[{"application": ..., "name": ...}]`) understood by `Backend.DeletePipeline`.
+func orphanedPipelinesJSON(orphans []backend.ApplicationPipelines) (string, error) {
+	var pipelines []map[string]interface{}
+
+	for _, application := range orphans {
+		for _, name := range application.Names {
+			pipelines = append(pipelines, map[string]interface{}{
+				"application": application.Application,
+				"name":        name,
+			})
+		}
+	}
+
+	return jsoniter.MarshalToString(pipelines)
+}
@@ -13,6 +13,7 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewRenderCommand(d *Dependencies) *cobra.Command {
 	var renderValues string
+	var renderFlags RenderFlags
 
 	cmd := &cobra.Command{
 		Use:   "render",
@@ -21,7 +22,7 @@ func NewRenderCommand(d *Dependencies) *cobra.Command {
 Automatically reads libraries from "vendor/". The Jsonnet-Bundler default path for libraries`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			settingsBytes, err := config.LoadConfig(d.Project, renderValues, "render")
+			settingsBytes, err := config.LoadProfileConfig(d.Project, renderValues, "render", ProfileName(cmd))
 
 			var confErr *config.FileConfErr
 
@@ -29,6 +30,12 @@ Automatically reads libraries from "vendor/". The Jsonnet-Bundler default path f
 				return err
 			}
 
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
 			pipeline, err := Render(d, settingsBytes, renderer.MainFileName)
 
 			if err != nil {
@@ -40,7 +47,9 @@ Automatically reads libraries from "vendor/". The Jsonnet-Bundler default path f
 		},
 	}
 
-	cmd.Flags().StringVarP(&renderValues, "values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&renderValues, "values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+
+	renderFlags.AddFlags(cmd)
 
 	return cmd
 }
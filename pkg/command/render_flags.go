@@ -0,0 +1,75 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+)
+
+// RenderFlags - Renderer inputs provided on the command line.
+// The flags are merged into the reserved keys of the render arguments (see `renderer.ExtVarsKey`).
+type RenderFlags struct {
+	ExtStr   []string
+	ExtCode  []string
+	TLAFiles []string
+}
+
+// AddFlags - Registers the render flags on a command.
+func (f *RenderFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.ExtStr, "ext-str", []string{}, "An external string variable passed to the renderer (key=value). May be repeated.")
+	cmd.Flags().StringArrayVar(&f.ExtCode, "ext-code", []string{}, "An external code variable passed to the renderer (key=code). May be repeated.")
+	cmd.Flags().StringArrayVar(&f.TLAFiles, "tla-file", []string{}, "A top level argument read from a file, relative to the project (key=path). May be repeated.")
+}
+
+// Apply - Merges the render flags into the render arguments.
+// Values provided on the command line take precedence over the values from the render config.
+func (f *RenderFlags) Apply(settings []byte) ([]byte, error) {
+	if len(f.ExtStr) == 0 && len(f.ExtCode) == 0 && len(f.TLAFiles) == 0 {
+		return settings, nil
+	}
+
+	args := make(map[string]interface{})
+
+	if len(settings) > 0 {
+		if err := jsoniter.Unmarshal(settings, &args); err != nil {
+			return nil, fmt.Errorf("render arguments must be an object to use renderer flags: %w", err)
+		}
+	}
+
+	flagsToKeys := []struct {
+		key    string
+		values []string
+	}{
+		{renderer.ExtVarsKey, f.ExtStr},
+		{renderer.ExtCodeKey, f.ExtCode},
+		{renderer.TLAFilesKey, f.TLAFiles},
+	}
+
+	for _, flag := range flagsToKeys {
+		if len(flag.values) == 0 {
+			continue
+		}
+
+		values, ok := args[flag.key].(map[string]interface{})
+		if !ok {
+			values = make(map[string]interface{})
+		}
+
+		for _, keyValue := range flag.values {
+			key, value, found := strings.Cut(keyValue, "=")
+
+			if !found || key == "" {
+				return nil, fmt.Errorf("invalid value %q for the %q renderer input, expected key=value", keyValue, flag.key)
+			}
+
+			values[key] = value
+		}
+
+		args[flag.key] = values
+	}
+
+	return jsoniter.Marshal(args)
+}
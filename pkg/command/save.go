@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Autodesk/shore/pkg/backend"
 	"github.com/Autodesk/shore/pkg/config"
 	"github.com/Autodesk/shore/pkg/renderer"
 	"github.com/spf13/cobra"
@@ -14,26 +15,38 @@ import (
 // Abstraction for different configuration languages (I.E. Jsonnet/HCL/CUELang)
 func NewSaveCommand(d *Dependencies) *cobra.Command {
 	var renderVals string
+	var renderFlags RenderFlags
+	var saveOptions backend.SaveOptions
 
 	cmd := &cobra.Command{
 		Use:   "save",
 		Short: "Save the pipeline",
 		Long:  "Using the main file configured by the renderer save the pipeline (or pipelines)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settingsBytes, err := config.LoadConfig(d.Project, renderVals, "render")
+			settingsBytes, err := config.LoadProfileConfig(d.Project, renderVals, "render", ProfileName(cmd))
 
 			if err != nil && !errors.Is(err, os.ErrNotExist) {
 				return err
 			}
 
+			settingsBytes, err = renderFlags.Apply(settingsBytes)
+
+			if err != nil {
+				return err
+			}
+
 			pipeline, err := Render(d, settingsBytes, renderer.MainFileName)
 
 			if err != nil {
 				return err
 			}
 
+			if saveOptions.Owner, err = ProjectOwnership(d, renderer.MainFileName, ProfileName(cmd)); err != nil {
+				return err
+			}
+
 			d.Logger.Info("Calling Backend.SavePipeline")
-			res, err := d.Backend.SavePipeline(pipeline)
+			res, err := d.Backend.SavePipeline(pipeline, saveOptions)
 
 			if err != nil {
 				d.Logger.Warnf("Save pipeline returned an error: %v", err)
@@ -46,7 +59,35 @@ func NewSaveCommand(d *Dependencies) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string for the render. If not provided the render.[json/yml/yaml] file is used.")
+	cmd.Flags().StringVarP(&renderVals, "render-values", "r", "", "A JSON string (or a file path) for the render, deep merged on top of the render.[json/yml/yaml] file and the profile overrides.")
+
+	renderFlags.AddFlags(cmd)
+	AddSaveFlags(cmd, &saveOptions)
 
 	return cmd
 }
+
+// AddSaveFlags - Registers the flags handling pipelines referenced by name that don't exist.
+func AddSaveFlags(cmd *cobra.Command, options *backend.SaveOptions) {
+	cmd.Flags().BoolVar(&options.CreateMissing, "create-missing", false, "Create empty placeholder pipelines for the referenced pipelines that don't exist.")
+	cmd.Flags().BoolVar(&options.AllowUnbound, "allow-unbound", false, "Save references to pipelines that don't exist as unbound (`null`).")
+}
+
+// ProjectOwnership - The ownership stamped on the pipelines rendered from the project's `renderType` entrypoint with `profile`.
+//
+// `nil` when the project has no `name` in shore.yml, the directory of the project doesn't identify it (I.E. `pipelines`
+// or a CI workspace), the pipelines aren't stamped then.
+func ProjectOwnership(d *Dependencies, renderType renderer.RenderType, profile string) (*backend.Ownership, error) {
+	projectName, err := config.LoadProjectName(d.Project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if projectName == "" {
+		d.Logger.Info("The project has no \"name\" in shore.yml, the saved pipelines aren't stamped with their owner")
+		return nil, nil
+	}
+
+	return &backend.Ownership{Project: projectName, Source: renderType.String(), Profile: profile}, nil
+}
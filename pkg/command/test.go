@@ -8,7 +8,6 @@ import (
 	"github.com/Autodesk/shore/pkg/config"
 	"github.com/Autodesk/shore/pkg/shore_testing"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/spf13/cobra"
 )
 
@@ -29,14 +28,14 @@ func NewTestRemoteCommand(d *Dependencies) *cobra.Command {
 		Short: "Run the test suite on a remotely saved pipeline",
 		Long:  "Using the E2E.yaml file run a full test-suite on the pipeline stored in a specific backend",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			testSettingsBytes, err := config.LoadConfig(d.Project, "", "E2E")
+			testSettingsBytes, err := config.LoadProfileConfig(d.Project, "", "E2E", ProfileName(cmd))
 
 			if err != nil {
 				return err
 			}
 
 			var testConfig shore_testing.TestsConfig
-			if err := jsoniter.Unmarshal(testSettingsBytes, &testConfig); err != nil {
+			if err := config.DecodeStrict(testSettingsBytes, &testConfig); err != nil {
 				return err
 			}
 
@@ -11,7 +11,6 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/spf13/afero"
-	"gopkg.in/yaml.v2"
 )
 
 // ShoreConfig - A structure representing the Shore Config.
@@ -56,24 +55,10 @@ func findIndividualLocalConfig(p *project.Project, configName string) (string, e
 	return "", nil
 }
 
-func writeShoreConfig(p *project.Project, shoreConfig ShoreConfig) error {
-	shoreConfigBytes, err := yaml.Marshal(shoreConfig)
-	if err != nil {
-		return err
-	}
-
-	projectPath, err := p.GetProjectPath()
-	if err != nil {
-		return err
-	}
-
-	shoreConfigPath := path.Join(projectPath, "shore.yml")
-	afero.WriteFile(p.FS, shoreConfigPath, shoreConfigBytes, os.ModePerm)
-	p.Log.Debugf("Created a new shore config located at: %s", shoreConfigPath)
-	return nil
-}
-
 // LoadShoreConfig - Loads the Shore Config given a Project obj.
+//
+// When the project has no `shore.yml` (I.E. created before `shore project init` generated one),
+// a default config is built from the project's config files, nothing is written to the project.
 func LoadShoreConfig(p *project.Project) (ShoreConfig, error) {
 	var shoreConfig ShoreConfig
 
@@ -146,11 +131,6 @@ func LoadShoreConfig(p *project.Project) (ShoreConfig, error) {
 
 		p.Log.Debug("Loaded default Shore Config: ", shoreConfig)
 
-		writeShoreConfig(p, shoreConfig)
-		if err != nil {
-			return ShoreConfig{}, err
-		}
-
 		return shoreConfig, nil
 	}
 
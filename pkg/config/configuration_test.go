@@ -111,7 +111,7 @@ func TestLoadShoreConfigDefault(t *testing.T) {
 		assert.Equal(t, path.Join(testPath, "exec.yml"), shoreConfig.Profiles[`default`].(map[string]interface{})[`exec`])
 		assert.Equal(t, path.Join(testPath, "E2E.json"), shoreConfig.Profiles[`default`].(map[string]interface{})[`e2e`])
 		assert.Nil(t, configErr)
-		assert.True(t, shoreConfigExists)
+		assert.False(t, shoreConfigExists)
 	})
 }
 
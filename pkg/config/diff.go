@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Autodesk/shore/pkg/project"
+)
+
+// LoadDiffIgnorePaths - `diff.ignore` in `shore.yml`, the paths ignored when comparing pipelines (I.E. `shore diff`).
+func LoadDiffIgnorePaths(p *project.Project) ([]string, error) {
+	shoreConfig, err := readShoreConfigFile(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	diffConfig, _ := shoreConfig["diff"].(map[string]interface{})
+	ignore, _ := diffConfig["ignore"].([]interface{})
+	paths := make([]string, 0, len(ignore))
+
+	for _, path := range ignore {
+		pathString, isString := path.(string)
+
+		if !isString {
+			return nil, fmt.Errorf("diff.ignore in shore.yml must be a list of paths, found %v", path)
+		}
+
+		paths = append(paths, pathString)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDiffIgnorePaths(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+diff:
+  ignore:
+    - triggers[*].lastSuccessfulExecution
+    - stages[*].refId
+profiles:
+  default:
+    render: render.yml
+`), os.ModePerm)
+
+		// Test
+		paths, err := LoadDiffIgnorePaths(proj)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"triggers[*].lastSuccessfulExecution", "stages[*].refId"}, paths)
+	})
+}
+
+func TestLoadDiffIgnorePathsWithoutShoreConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Test
+		paths, err := LoadDiffIgnorePaths(proj)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Empty(t, paths)
+	})
+}
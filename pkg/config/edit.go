@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/project"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// GetShoreConfigValue - Returns the value of a dotted key (I.E. `profiles.default.render`) of the project's shore config.
+func GetShoreConfigValue(p *project.Project, key string) (interface{}, error) {
+	shoreConfigPath, err := shoreConfigFilePath(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	configData, err := ReadConfigFile(p, shoreConfigPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	if err := jsoniter.Unmarshal(configData, &value); err != nil {
+		return nil, err
+	}
+
+	for _, keyPart := range strings.Split(key, ".") {
+		values, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %s", key, filepath.Base(shoreConfigPath))
+		}
+
+		if value, ok = values[keyPart]; !ok {
+			return nil, fmt.Errorf("key %q not found in %s", key, filepath.Base(shoreConfigPath))
+		}
+	}
+
+	return value, nil
+}
+
+// SetShoreConfigValue - Sets the value of a dotted key (I.E. `executor.config.prod`) in the project's shore config.
+//
+// The value is parsed as YAML (`true` is a boolean, `[a, b]` a list), the key order, comments & formatting of the file are kept.
+// The shore config is validated before it is written, `shore.yml` is created if the project doesn't have a shore config.
+func SetShoreConfigValue(p *project.Project, key string, value string) error {
+	shoreConfigPath, err := shoreConfigFilePath(p)
+
+	if os.IsNotExist(err) {
+		projectPath, err := p.GetProjectPath()
+
+		if err != nil {
+			return err
+		}
+
+		shoreConfigPath = filepath.Join(projectPath, project.ShoreConfigFileName)
+	} else if err != nil {
+		return err
+	}
+
+	if filepath.Ext(shoreConfigPath) == ".json" {
+		return fmt.Errorf("%s can't be edited, only YAML shore configs are supported", filepath.Base(shoreConfigPath))
+	}
+
+	var shoreConfig yaml.Node
+
+	if data, err := afero.ReadFile(p.FS, shoreConfigPath); err == nil {
+		if err := yaml.Unmarshal(data, &shoreConfig); err != nil {
+			return err
+		}
+	}
+
+	// A new (or empty) shore config.
+	if len(shoreConfig.Content) == 0 {
+		shoreConfig = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	var parsedValue yaml.Node
+
+	if err := yaml.Unmarshal([]byte(value), &parsedValue); err != nil {
+		return fmt.Errorf("invalid value %q: %w", value, err)
+	}
+
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+
+	if len(parsedValue.Content) > 0 {
+		valueNode = parsedValue.Content[0]
+	}
+
+	if err := setNodeValue(shoreConfig.Content[0], strings.Split(key, "."), valueNode); err != nil {
+		return fmt.Errorf("failed to set %q: %w", key, err)
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&shoreConfig); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	data := buffer.Bytes()
+
+	if err := validateShoreConfigData(p, filepath.Base(shoreConfigPath), data); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(p.FS, shoreConfigPath, data, os.ModePerm)
+}
+
+// ValidateShoreConfig - Validates the project's shore config against its schema.
+// Also checks the configuration files of the profiles exist and validates them (merged with their base files).
+func ValidateShoreConfig(p *project.Project) error {
+	shoreConfigPath, err := shoreConfigFilePath(p)
+
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the project has no shore config, run `shore project init` to create one")
+	} else if err != nil {
+		return err
+	}
+
+	data, err := afero.ReadFile(p.FS, shoreConfigPath)
+
+	if err != nil {
+		return err
+	}
+
+	fileName := filepath.Base(shoreConfigPath)
+
+	if err := validateShoreConfigData(p, fileName, data); err != nil {
+		return err
+	}
+
+	shoreConfig, err := LoadShoreConfig(p)
+
+	if err != nil {
+		return err
+	}
+
+	projectDir := filepath.Dir(shoreConfigPath)
+	schemaErrors := &SchemaErrors{Config: "shore"}
+
+	for _, profileName := range sortedKeys(shoreConfig.Profiles) {
+		profile, _ := shoreConfig.Profiles[profileName].(map[string]interface{})
+
+		for _, key := range profileConfigKeys {
+			configPath, ok := profile[key].(string)
+
+			if !ok {
+				continue
+			}
+
+			if !filepath.IsAbs(configPath) {
+				configPath = filepath.Join(projectDir, configPath)
+			}
+
+			if exists, _ := afero.Exists(p.FS, configPath); !exists {
+				line, _ := findLine(parseLayerNode(p.FS, configLayer{path: shoreConfigPath}), fmt.Sprintf("profiles.%s.%s", profileName, key))
+
+				schemaErrors.Errors = append(schemaErrors.Errors, SchemaError{
+					File:    fileName,
+					Line:    line,
+					Path:    fmt.Sprintf("profiles.%s.%s", profileName, key),
+					Message: fmt.Sprintf("file %q does not exist", profile[key]),
+				})
+			}
+		}
+	}
+
+	if len(schemaErrors.Errors) > 0 {
+		return schemaErrors
+	}
+
+	return validateProfileConfigs(p, sortedKeys(shoreConfig.Profiles))
+}
+
+// validateProfileConfigs - Validates the configuration files of the profiles, base files shared by profiles are reported once.
+func validateProfileConfigs(p *project.Project, profileNames []string) error {
+	var errs []error
+	reported := make(map[string]bool)
+
+	for _, profileName := range profileNames {
+		for _, key := range profileConfigKeys {
+			_, err := LoadProfileConfig(p, "", profileConfigFileNames[key], profileName)
+
+			var confErr *FileConfErr
+
+			if err == nil || errors.As(err, &confErr) || reported[err.Error()] {
+				continue
+			}
+
+			reported[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateShoreConfigData - Validates the content of a shore config (JSON or YAML) against its schema.
+func validateShoreConfigData(p *project.Project, fileName string, data []byte) error {
+	var config interface{}
+
+	if filepath.Ext(fileName) == ".json" {
+		if err := jsoniter.Unmarshal(data, &config); err != nil {
+			return err
+		}
+	} else if err := yaml.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	// Normalize the YAML maps (`map[interface{}]interface{}`) into JSON objects.
+	jsonData, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(config)
+
+	if err != nil {
+		return err
+	}
+
+	return validateConfig(p, "shore", []configLayer{{source: fileName, data: jsonData, raw: data}}, jsonData)
+}
+
+// shoreConfigFilePath - The path of the project's shore config, `os.ErrNotExist` when the project has none.
+func shoreConfigFilePath(p *project.Project) (string, error) {
+	shoreConfigPath, err := findIndividualLocalConfig(p, "shore")
+
+	if err != nil {
+		return "", err
+	}
+
+	if shoreConfigPath == "" {
+		return "", os.ErrNotExist
+	}
+
+	return shoreConfigPath, nil
+}
+
+// setNodeValue - Sets a nested value in a YAML mapping node, creating the missing mappings.
+func setNodeValue(mapping *yaml.Node, keys []string, value *yaml.Node) error {
+	if mapping.Kind != yaml.MappingNode {
+		return errors.New("the shore config is not a map")
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != keys[0] {
+			continue
+		}
+
+		if len(keys) == 1 {
+			// The comments belong to the key, the ones of the replaced value are kept.
+			value.LineComment = mapping.Content[i+1].LineComment
+			mapping.Content[i+1] = value
+			return nil
+		}
+
+		child := mapping.Content[i+1]
+
+		// An empty value (I.E. `profiles:`) becomes a map.
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: child.HeadComment, LineComment: child.LineComment}
+		}
+
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("%q is not a map", keys[0])
+		}
+
+		return setNodeValue(child, keys[1:], value)
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[0]}
+
+	if len(keys) == 1 {
+		mapping.Content = append(mapping.Content, keyNode, value)
+		return nil
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, keyNode, child)
+
+	return setNodeValue(child, keys[1:], value)
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetShoreConfigValue(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+
+		// Test
+		value, err := GetShoreConfigValue(proj, "profiles.staging.render")
+		_, missingErr := GetShoreConfigValue(proj, "profiles.prod.render")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "render.staging.yml", value)
+		assert.EqualError(t, missingErr, `key "profiles.prod.render" not found in shore.yml`)
+	})
+}
+
+func TestSetShoreConfigValue(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+
+		// Test
+		err := SetShoreConfigValue(proj, "profiles.prod.render", "render.prod.yml")
+
+		// Assert
+		shoreConfig, _ := afero.ReadFile(proj.FS, path.Join(testPath, "shore.yml"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, testShoreConfig+"  prod:\n    render: render.prod.yml\n", string(shoreConfig))
+	})
+}
+
+func TestSetShoreConfigValueKeepsComments(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(`# The project configuration.
+renderer:
+  type: jsonnet # The only renderer.
+executor:
+  type: spinnaker
+  config:
+    default: ~/.spin/config # Local Spinnaker.
+profiles:
+  # Used when no profile is given.
+  default:
+    render: render.yml
+    exec: exec.yml # Overridden by --exec.
+`), os.ModePerm)
+
+		// Test
+		err := SetShoreConfigValue(proj, "profiles.default.exec", "exec.default.yml")
+		nestedErr := SetShoreConfigValue(proj, "profiles.default.render.file", "render.yml")
+
+		// Assert
+		shoreConfig, _ := afero.ReadFile(proj.FS, path.Join(testPath, "shore.yml"))
+
+		assert.Nil(t, err)
+		assert.EqualError(t, nestedErr, `failed to set "profiles.default.render.file": "render" is not a map`)
+		assert.Equal(t, `# The project configuration.
+renderer:
+  type: jsonnet # The only renderer.
+executor:
+  type: spinnaker
+  config:
+    default: ~/.spin/config # Local Spinnaker.
+profiles:
+  # Used when no profile is given.
+  default:
+    render: render.yml
+    exec: exec.default.yml # Overridden by --exec.
+`, string(shoreConfig))
+	})
+}
+
+func TestSetShoreConfigInvalidValue(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+
+		// Test
+		err := SetShoreConfigValue(proj, "renderer.type", "cue")
+
+		// Assert
+		shoreConfig, _ := afero.ReadFile(proj.FS, path.Join(testPath, "shore.yml"))
+
+		assert.EqualError(t, err, "invalid shore configuration:\n\tshore.yml:2: renderer.type: unsupported value cue, expected one of: jsonnet")
+		assert.Equal(t, testShoreConfig, string(shoreConfig))
+	})
+}
+
+func TestValidateShoreConfigMissingFiles(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.yml"), []byte("{}"), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "exec.yml"), []byte("{}"), os.ModePerm)
+
+		// Test
+		err := ValidateShoreConfig(proj)
+
+		// Assert
+		assert.EqualError(t, err, "invalid shore configuration:\n\tshore.yml:13: profiles.staging.render: file \"render.staging.yml\" does not exist")
+	})
+}
+
+func TestValidateShoreConfigWithoutShoreConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Test
+		err := ValidateShoreConfig(proj)
+
+		// Assert
+		assert.EqualError(t, err, "the project has no shore config, run `shore project init` to create one")
+	})
+}
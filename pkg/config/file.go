@@ -54,6 +54,10 @@ func GetFileConfig(p *project.Project, fileName string) ([]byte, error) {
 }
 
 // ReadConfigFile - Reads in a config. Supports json/yaml/yml
+//
+// `${<provider>:<key>}` references in string values are resolved by the registered `SecretProvider`s
+// (I.E. `${env:TOKEN}`, `${file:token.txt}`, `${encrypted-file:secrets.enc#token}`), use `$${...}` for a literal `${...}`.
+// The resolved values are masked in logs and error messages (see `MaskSecrets`).
 func ReadConfigFile(p *project.Project, filePath string) ([]byte, error) {
 	data, err := afero.ReadFile(p.FS, filePath)
 	extension := filepath.Ext(filePath)
@@ -77,6 +81,10 @@ func ReadConfigFile(p *project.Project, filePath string) ([]byte, error) {
 		}
 	}
 
+	if config, err = interpolate(p.FS, filePath, filepath.Dir(filePath), config); err != nil {
+		return nil, err
+	}
+
 	data, err = json.Marshal(config)
 	return data, err
 }
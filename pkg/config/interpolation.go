@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// MaskedValue - Replaces the secrets in logs and error messages.
+const MaskedValue = "********"
+
+// minSecretLength - Shorter values (I.E. `us`, `true`) aren't masked, masking them would garble the output.
+const minSecretLength = 6
+
+// interpolationRegexp - `${<provider>:<key>}`, escaped with `$${...}`.
+// References to unknown providers are kept as is, SpEL expressions (`${ parameters["name"] }`) are never interpolated.
+var interpolationRegexp = regexp.MustCompile(`\$?\$\{([a-zA-Z][a-zA-Z0-9_-]*):([^}]*)\}`)
+
+// InterpolationErr - A reference in a configuration file that couldn't be resolved.
+type InterpolationErr struct {
+	File      string
+	Reference string
+	Err       error
+}
+
+func (e *InterpolationErr) Error() string {
+	return fmt.Sprintf("failed to resolve %s in %s: %v", e.Reference, e.File, e.Err)
+}
+
+func (e *InterpolationErr) Unwrap() error {
+	return e.Err
+}
+
+// interpolate - Resolves the `${<provider>:<key>}` references in the string values of a decoded config.
+func interpolate(fs afero.Fs, filePath string, baseDir string, value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return interpolateString(fs, filePath, baseDir, typedValue)
+	case map[string]interface{}:
+		for key, child := range typedValue {
+			interpolated, err := interpolate(fs, filePath, baseDir, child)
+
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = interpolated
+		}
+	case map[interface{}]interface{}:
+		for key, child := range typedValue {
+			interpolated, err := interpolate(fs, filePath, baseDir, child)
+
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = interpolated
+		}
+	case []interface{}:
+		for i, child := range typedValue {
+			interpolated, err := interpolate(fs, filePath, baseDir, child)
+
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[i] = interpolated
+		}
+	}
+
+	return value, nil
+}
+
+func interpolateString(fs afero.Fs, filePath string, baseDir string, value string) (string, error) {
+	var interpolateErr error
+
+	interpolated := interpolationRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+		if interpolateErr != nil {
+			return reference
+		}
+
+		if strings.HasPrefix(reference, "$$") {
+			return reference[1:]
+		}
+
+		match := interpolationRegexp.FindStringSubmatch(reference)
+		provider, exists := getSecretProvider(match[1])
+
+		if !exists {
+			return reference
+		}
+
+		resolved, err := provider.Provider.Resolve(fs, baseDir, match[2])
+
+		if err != nil {
+			interpolateErr = &InterpolationErr{File: filePath, Reference: reference, Err: err}
+			return reference
+		}
+
+		if provider.Secret {
+			RegisterSecret(resolved)
+		}
+
+		return resolved
+	})
+
+	return interpolated, interpolateErr
+}
+
+var secrets = struct {
+	sync.RWMutex
+	values map[string]struct{}
+}{values: map[string]struct{}{}}
+
+// RegisterSecret - Masks a value in logs and error messages (see `MaskSecrets`).
+//
+// Values shorter than `minSecretLength` aren't masked.
+func RegisterSecret(value string) {
+	if len(strings.TrimSpace(value)) < minSecretLength {
+		return
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+
+	secrets.values[value] = struct{}{}
+}
+
+// MaskSecrets - Replaces the registered secrets (I.E. the values of the secret providers) in a string.
+func MaskSecrets(value string) string {
+	secrets.RLock()
+	defer secrets.RUnlock()
+
+	if len(secrets.values) == 0 {
+		return value
+	}
+
+	// Mask the longest secrets first, a secret may contain another one.
+	sortedSecrets := make([]string, 0, len(secrets.values))
+
+	for secret := range secrets.values {
+		sortedSecrets = append(sortedSecrets, secret)
+	}
+
+	sort.Slice(sortedSecrets, func(i, j int) bool {
+		return len(sortedSecrets[i]) > len(sortedSecrets[j])
+	})
+
+	for _, secret := range sortedSecrets {
+		value = strings.ReplaceAll(value, secret, MaskedValue)
+	}
+
+	return value
+}
+
+// MaskingHook - A logrus hook masking the registered secrets in the log messages and fields.
+type MaskingHook struct{}
+
+// Levels - All the log levels are masked.
+func (MaskingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire - Masks the entry before it is formatted.
+func (MaskingHook) Fire(entry *logrus.Entry) error {
+	entry.Message = MaskSecrets(entry.Message)
+
+	for key, value := range entry.Data {
+		switch typedValue := value.(type) {
+		case error:
+			entry.Data[key] = MaskSecrets(typedValue.Error())
+		case string:
+			entry.Data[key] = MaskSecrets(typedValue)
+		default:
+			if masked := MaskSecrets(fmt.Sprint(value)); masked != fmt.Sprint(value) {
+				entry.Data[key] = masked
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadConfigFileInterpolation(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		t.Setenv("SHORE_TEST_TOKEN", "env-token-value")
+		afero.WriteFile(proj.FS, path.Join(testPath, "secrets", "token.txt"), []byte("file-token-value\n"), 0644)
+		afero.WriteFile(proj.FS, path.Join(testPath, "secrets", "exec.yml"), []byte(`
+token: "${env:SHORE_TEST_TOKEN}"
+headers:
+  - "Bearer ${file:token.txt}"
+literal: "$${env:SHORE_TEST_TOKEN}"
+spel: '${ parameters["test"] }'
+unknown: "${vault:secret/token}"
+`), 0644)
+
+		// Test
+		values, err := ReadConfigFile(proj, path.Join(testPath, "secrets", "exec.yml"))
+
+		// Assert
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"token": "env-token-value",
+			"headers": ["Bearer file-token-value"],
+			"literal": "${env:SHORE_TEST_TOKEN}",
+			"spel": "${ parameters[\"test\"] }",
+			"unknown": "${vault:secret/token}"
+		}`, string(values))
+	})
+}
+
+func TestReadConfigFileInterpolationMissingEnv(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		os.Unsetenv("SHORE_TEST_MISSING")
+		afero.WriteFile(proj.FS, path.Join(testPath, "exec.yml"), []byte(`token: "${env:SHORE_TEST_MISSING}"`), 0644)
+
+		// Test
+		_, err := GetFileConfig(proj, "exec")
+
+		// Assert
+		var interpolationErr *InterpolationErr
+
+		assert.EqualError(t, err, `failed to resolve ${env:SHORE_TEST_MISSING} in /test/exec.yml: environment variable "SHORE_TEST_MISSING" is not set`)
+		assert.True(t, errors.As(err, &interpolationErr))
+	})
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		RegisterSecretProvider("test-vault", SecretProviderFunc(func(fs afero.Fs, baseDir string, key string) (string, error) {
+			return "vault-" + key, nil
+		}))
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.json"), []byte(`{"token": "${test-vault:token}"}`), 0644)
+
+		// Test
+		values, err := GetFileConfig(proj, "render")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `{"token":"vault-token"}`, string(values))
+		assert.Contains(t, SecretProviderNames(), "test-vault")
+	})
+}
+
+func TestReadConfigFileInterpolationMasking(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		t.Setenv("SHORE_TEST_MASKING_TOKEN", "masking-env-token")
+		t.Setenv("SHORE_TEST_MASKING_REGION", "us")
+		afero.WriteFile(proj.FS, path.Join(testPath, "masking-token.txt"), []byte("masking-file-token\n"), 0644)
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.json"), []byte(`{
+			"token": "${env:SHORE_TEST_MASKING_TOKEN}",
+			"fileToken": "${file:masking-token.txt}",
+			"region": "${env:SHORE_TEST_MASKING_REGION}"
+		}`), 0644)
+
+		logger := logrus.New()
+		logger.Out = io.Discard
+		logger.Level = logrus.DebugLevel
+		logger.AddHook(MaskingHook{})
+		hook := test.NewLocal(logger)
+
+		// Test
+		_, err := GetFileConfig(proj, "render")
+		logger.WithError(errors.New("bad masking-env-token")).Debug("token masking-env-token")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "********, ********, us", MaskSecrets("masking-env-token, masking-file-token, us"))
+		assert.Equal(t, "token ********", hook.LastEntry().Message)
+		assert.Equal(t, "bad ********", hook.LastEntry().Data[logrus.ErrorKey])
+	})
+}
+
+func TestMaskSecrets(t *testing.T) {
+	// Given
+	RegisterSecret("mask-secret")
+	RegisterSecret("mask-secret-longer")
+	RegisterSecret("")
+
+	// Test
+	masked := MaskSecrets("token=mask-secret-longer, other=mask-secret")
+
+	// Assert
+	assert.Equal(t, "token=********, other=********", masked)
+}
+
+func TestMaskingHook(t *testing.T) {
+	// Given
+	RegisterSecret("hook-secret")
+	logger := logrus.New()
+	logger.Out = io.Discard
+	logger.AddHook(MaskingHook{})
+	hook := test.NewLocal(logger)
+
+	// Test
+	logger.WithField("payload", map[string]string{"token": "hook-secret"}).WithError(errors.New("bad hook-secret")).Warn("token hook-secret")
+
+	// Assert
+	entry := hook.LastEntry()
+
+	assert.Equal(t, "token ********", entry.Message)
+	assert.Equal(t, "map[token:********]", entry.Data["payload"])
+	assert.Equal(t, "bad ********", entry.Data[logrus.ErrorKey])
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+var pathIndexRegexp = regexp.MustCompile(`\[(\d+)\]`)
+
+// locateSchemaErrors - Sets the file and the line of schema errors, the file is the last layer providing the value.
+func locateSchemaErrors(fs afero.Fs, schemaErrors []SchemaError, layers []configLayer) []SchemaError {
+	roots := make([]*yaml.Node, len(layers))
+
+	for i, layer := range layers {
+		roots[i] = parseLayerNode(fs, layer)
+	}
+
+	for i, schemaErr := range schemaErrors {
+		// Errors of the root object (I.E. a missing key) belong to no single layer of a merged config.
+		located := schemaErr.Path == "" && len(layers) > 1
+
+		for j := len(layers) - 1; j >= 0 && !located; j-- {
+			if line, found := findLine(roots[j], schemaErr.Path); found {
+				schemaErrors[i].File = layers[j].source
+				located = true
+
+				// Flags have no lines.
+				if layers[j].path != "" || layers[j].raw != nil {
+					schemaErrors[i].Line = line
+				}
+			}
+		}
+
+		// A value no layer provides (I.E. a missing key of the root object).
+		if !located && len(layers) == 1 {
+			schemaErrors[i].File = layers[0].source
+		}
+	}
+
+	return schemaErrors
+}
+
+// parseLayerNode - The YAML tree of a layer (JSON is valid YAML), `nil` when it can't be parsed.
+func parseLayerNode(fs afero.Fs, layer configLayer) *yaml.Node {
+	content := layer.raw
+
+	if content == nil && layer.path != "" {
+		content, _ = afero.ReadFile(fs, layer.path)
+	}
+
+	if content == nil {
+		content = layer.data
+	}
+
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(content, &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+
+	return document.Content[0]
+}
+
+// findLine - The line of a dotted path (I.E. `tests.deploy.assertions[0]`) in a YAML tree.
+// Object values are located by their key.
+func findLine(root *yaml.Node, path string) (int, bool) {
+	if root == nil {
+		return 0, false
+	}
+
+	node := root
+	line := root.Line
+
+	if path == "" {
+		return line, true
+	}
+
+	for _, segment := range splitPath(path) {
+		for node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			found := false
+
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					line = node.Content[i].Line
+					node = node.Content[i+1]
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return 0, false
+			}
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+
+			if err != nil || !strings.HasPrefix(segment, "[") || index >= len(node.Content) {
+				return 0, false
+			}
+
+			node = node.Content[index]
+			line = node.Line
+		default:
+			return 0, false
+		}
+	}
+
+	return line, true
+}
+
+// splitPath - `a.b[0].c` -> `a`, `b`, `[0]`, `c`
+func splitPath(path string) []string {
+	var segments []string
+
+	for _, part := range strings.Split(path, ".") {
+		key := pathIndexRegexp.ReplaceAllString(part, "")
+
+		if key != "" {
+			segments = append(segments, key)
+		}
+
+		segments = append(segments, pathIndexRegexp.FindAllString(part, -1)...)
+	}
+
+	return segments
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ListMergeStrategy - How lists are merged when a configuration layer overrides another one.
+type ListMergeStrategy string
+
+const (
+	// ListMergeReplace - The overriding list replaces the base list (default).
+	ListMergeReplace ListMergeStrategy = "replace"
+	// ListMergeAppend - The overriding list is appended to the base list.
+	ListMergeAppend ListMergeStrategy = "append"
+	// ListMergeByIndex - Items are merged by their index, extra items of either list are kept.
+	ListMergeByIndex ListMergeStrategy = "merge"
+)
+
+// ListMergeStrategies - The supported list merge strategies.
+var ListMergeStrategies = []ListMergeStrategy{ListMergeReplace, ListMergeAppend, ListMergeByIndex}
+
+// ParseListMergeStrategy - Parses a list merge strategy, an empty value is `ListMergeReplace`.
+func ParseListMergeStrategy(value string) (ListMergeStrategy, error) {
+	if value == "" {
+		return ListMergeReplace, nil
+	}
+
+	names := make([]string, 0, len(ListMergeStrategies))
+
+	for _, strategy := range ListMergeStrategies {
+		if string(strategy) == value {
+			return strategy, nil
+		}
+
+		names = append(names, string(strategy))
+	}
+
+	return "", fmt.Errorf("unsupported list merge strategy %q, expected one of: %s", value, strings.Join(names, ", "))
+}
+
+// MergeConfigs - Deep merges decoded JSON configs, later layers override earlier ones.
+//
+// Objects are merged key by key, lists follow the `lists` strategy, any other value (including `null`)
+// replaces the base value. The layers are never modified.
+func MergeConfigs(lists ListMergeStrategy, layers ...interface{}) interface{} {
+	var merged interface{}
+
+	for i, layer := range layers {
+		if i == 0 {
+			merged = copyConfig(layer)
+			continue
+		}
+
+		merged = mergeValues(merged, layer, lists)
+	}
+
+	return merged
+}
+
+func mergeValues(base interface{}, override interface{}, lists ListMergeStrategy) interface{} {
+	switch overrideValue := override.(type) {
+	case map[string]interface{}:
+		baseMap, ok := base.(map[string]interface{})
+
+		if !ok {
+			return copyConfig(overrideValue)
+		}
+
+		keys := make([]string, 0, len(overrideValue))
+
+		for key := range overrideValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if baseValue, exists := baseMap[key]; exists {
+				baseMap[key] = mergeValues(baseValue, overrideValue[key], lists)
+			} else {
+				baseMap[key] = copyConfig(overrideValue[key])
+			}
+		}
+
+		return baseMap
+	case []interface{}:
+		baseList, ok := base.([]interface{})
+
+		if !ok {
+			return copyConfig(overrideValue)
+		}
+
+		switch lists {
+		case ListMergeAppend:
+			return append(baseList, copyConfig(overrideValue).([]interface{})...)
+		case ListMergeByIndex:
+			for i, item := range overrideValue {
+				if i < len(baseList) {
+					baseList[i] = mergeValues(baseList[i], item, lists)
+				} else {
+					baseList = append(baseList, copyConfig(item))
+				}
+			}
+
+			return baseList
+		}
+
+		return copyConfig(overrideValue)
+	}
+
+	return override
+}
+
+// copyConfig - A deep copy of a decoded JSON value, so merging never modifies a layer.
+func copyConfig(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typedValue))
+
+		for key, child := range typedValue {
+			copied[key] = copyConfig(child)
+		}
+
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typedValue))
+
+		for i, child := range typedValue {
+			copied[i] = copyConfig(child)
+		}
+
+		return copied
+	}
+
+	return value
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestConfig(config string) interface{} {
+	var value interface{}
+	jsoniter.Unmarshal([]byte(config), &value)
+	return value
+}
+
+func TestMergeConfigs(t *testing.T) {
+	// Given
+	base := decodeTestConfig(`{"application": "app", "params": {"region": "us-east-1", "replicas": 1}, "tags": ["a", "b"]}`)
+	override := decodeTestConfig(`{"params": {"replicas": 3, "debug": null}, "tags": ["c"], "pipeline": "prod"}`)
+
+	// Test
+	merged := MergeConfigs(ListMergeReplace, base, override)
+
+	// Assert
+	assert.Equal(t, decodeTestConfig(`{
+		"application": "app",
+		"pipeline": "prod",
+		"params": {"region": "us-east-1", "replicas": 3, "debug": null},
+		"tags": ["c"]
+	}`), merged)
+	assert.Equal(t, decodeTestConfig(`{"application": "app", "params": {"region": "us-east-1", "replicas": 1}, "tags": ["a", "b"]}`), base)
+}
+
+func TestMergeConfigsListStrategies(t *testing.T) {
+	// Given
+	base := decodeTestConfig(`{"stages": [{"name": "a", "wait": 1}, {"name": "b"}]}`)
+	override := decodeTestConfig(`{"stages": [{"wait": 2}]}`)
+
+	// Test
+	appended := MergeConfigs(ListMergeAppend, base, override)
+	mergedByIndex := MergeConfigs(ListMergeByIndex, base, override)
+
+	// Assert
+	assert.Equal(t, decodeTestConfig(`{"stages": [{"name": "a", "wait": 1}, {"name": "b"}, {"wait": 2}]}`), appended)
+	assert.Equal(t, decodeTestConfig(`{"stages": [{"name": "a", "wait": 2}, {"name": "b"}]}`), mergedByIndex)
+}
+
+func TestParseListMergeStrategy(t *testing.T) {
+	// Test
+	defaultStrategy, err := ParseListMergeStrategy("")
+	_, unknownErr := ParseListMergeStrategy("prepend")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, ListMergeReplace, defaultStrategy)
+	assert.EqualError(t, unknownErr, `unsupported list merge strategy "prepend", expected one of: replace, append, merge`)
+}
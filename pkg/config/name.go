@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/project"
+)
+
+// LoadProjectName - `name` in `shore.yml`, a name identifying the project across checkouts (I.E. in the ownership stamp of saved pipelines).
+//
+// Empty when the project has no name, an empty name is an error.
+func LoadProjectName(p *project.Project) (string, error) {
+	shoreConfig, err := readShoreConfigFile(p)
+
+	if err != nil {
+		return "", err
+	}
+
+	value, exists := shoreConfig["name"]
+
+	if !exists {
+		return "", nil
+	}
+
+	name, isString := value.(string)
+
+	if !isString || strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("the project \"name\" in shore.yml must be a non-empty string, got %q", fmt.Sprint(value))
+	}
+
+	return name, nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProjectName(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(`
+name: payments-pipelines
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+profiles:
+  default:
+    render: render.yml
+`), os.ModePerm)
+
+		// Test
+		name, err := LoadProjectName(proj)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "payments-pipelines", name)
+	})
+}
+
+func TestLoadProjectNameWithoutShoreConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Test
+		name, err := LoadProjectName(proj)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Empty(t, name)
+	})
+}
+
+func TestLoadProjectNameEmpty(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(`
+name: " "
+renderer:
+  type: jsonnet
+`), os.ModePerm)
+
+		// Test
+		_, err := LoadProjectName(proj)
+
+		// Assert
+		assert.EqualError(t, err, `the project "name" in shore.yml must be a non-empty string, got " "`)
+	})
+}
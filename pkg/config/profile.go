@@ -0,0 +1,279 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/project"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+)
+
+// configLayer - A configuration file (or flag) taking part in a layered config.
+type configLayer struct {
+	// source - The file (relative to the project) or the flag providing the layer.
+	source string
+	// path - The absolute path of the file, empty for a flag.
+	path string
+	// data - The decoded (and interpolated) layer, as JSON.
+	data []byte
+	// raw - The original content of the layer, for the line numbers of the schema errors (read from `path` when empty).
+	raw []byte
+}
+
+// LoadProfileConfig - Loads a layered config (I.E. `render`), deep merging in order:
+//   - The base file - `render.[json/yaml/yml]`
+//   - The profile's overrides - the file of the profile in `shore.yml`, or `render.<profile>.[json/yaml/yml]`
+//   - The flag - a JSON string or a file path (I.E. `--values`)
+//
+// Lists are merged following `merge.lists` in `shore.yml` (see `ListMergeStrategy`).
+// The result is validated against the schema of the config (I.E. `schemas/render.schema.json`), see `SchemaErrors`.
+// Returns the error of the base file when no layer exists.
+func LoadProfileConfig(p *project.Project, flag string, fileName string, profile string) ([]byte, error) {
+	layers, err := profileConfigLayers(p, fileName, profile)
+
+	var confErr *FileConfErr
+
+	// Without any file, the config may only be provided by the flag.
+	if err != nil && (flag == "" || !errors.As(err, &confErr)) {
+		return nil, err
+	}
+
+	if flag != "" {
+		flagData, err := GetFlagConfig(p, flag)
+
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, configLayer{source: "flag", data: flagData})
+	}
+
+	if len(layers) == 1 {
+		if err := validateConfig(p, fileName, layers, layers[0].data); err != nil {
+			return nil, err
+		}
+
+		return layers[0].data, nil
+	}
+
+	lists, err := loadListMergeStrategy(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergeLayers(layers, lists)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.Log.Debugf("Merged the %s config layers: %s", fileName, layerSources(layers))
+
+	data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(merged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateConfig(p, fileName, layers, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// validateConfig - Validates a (merged) config against the schema named after its file (I.E. `cleanup/exec` -> `exec`).
+// Configs without a schema are always valid.
+func validateConfig(p *project.Project, fileName string, layers []configLayer, data []byte) error {
+	schemaName := strings.ToLower(filepath.Base(fileName))
+
+	if !HasSchema(schemaName) {
+		return nil
+	}
+
+	schema, err := LoadSchema(schemaName)
+
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+
+	if err := jsoniter.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if schemaErrors := schema.Validate(value); len(schemaErrors) > 0 {
+		return &SchemaErrors{Config: fileName, Errors: locateSchemaErrors(p.FS, schemaErrors, layers)}
+	}
+
+	return nil
+}
+
+// profileConfigLayers - The base file and the profile's overrides of a layered config.
+//
+// Returns the error of the base file (a `FileConfErr`) when neither the base file nor the overrides exist.
+func profileConfigLayers(p *project.Project, fileName string, profile string) ([]configLayer, error) {
+	projectPath, err := p.GetProjectPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []configLayer
+
+	baseData, baseErr := GetFileConfig(p, fileName)
+
+	var confErr *FileConfErr
+
+	if baseErr != nil && !errors.As(baseErr, &confErr) {
+		return nil, baseErr
+	}
+
+	if baseErr == nil {
+		layers = append(layers, configLayer{source: fileName, data: baseData})
+
+		if basePath, found := findConfigFile(p, projectPath, fileName); found {
+			layers[0].source = relativePath(projectPath, basePath)
+			layers[0].path = basePath
+		}
+	}
+
+	overridePath, err := profileOverridePath(p, projectPath, fileName, profile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if overridePath != "" {
+		overrideData, err := ReadConfigFile(p, overridePath)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the %s config of the %q profile: %w", fileName, profile, err)
+		}
+
+		layers = append(layers, configLayer{source: relativePath(projectPath, overridePath), path: overridePath, data: overrideData})
+	}
+
+	if len(layers) == 0 {
+		return nil, baseErr
+	}
+
+	return layers, nil
+}
+
+// profileOverridePath - The file overriding the base file for a profile, empty when the profile has none.
+func profileOverridePath(p *project.Project, projectPath string, fileName string, profile string) (string, error) {
+	if profile == "" {
+		profile = "default"
+	}
+
+	shoreConfig, err := readShoreConfigFile(p)
+
+	if err != nil {
+		return "", err
+	}
+
+	profiles, _ := shoreConfig["profiles"].(map[string]interface{})
+	profileConfig, _ := profiles[profile].(map[string]interface{})
+
+	if configPath, ok := profileConfig[strings.ToLower(fileName)].(string); ok {
+		if !filepath.IsAbs(configPath) {
+			configPath = filepath.Join(projectPath, configPath)
+		}
+
+		// The profile points at the base file (I.E. the `default` profile).
+		if strings.TrimSuffix(configPath, filepath.Ext(configPath)) == filepath.Join(projectPath, fileName) {
+			return "", nil
+		}
+
+		return configPath, nil
+	}
+
+	overridePath, _ := findConfigFile(p, projectPath, fmt.Sprintf("%s.%s", fileName, profile))
+
+	return overridePath, nil
+}
+
+// findConfigFile - The path of a config file, trying the `.json/.yaml/.yml` extensions in order.
+func findConfigFile(p *project.Project, projectPath string, fileName string) (string, bool) {
+	for _, ext := range getExtensions() {
+		filePath := filepath.Join(projectPath, fmt.Sprintf("%s.%s", fileName, ext))
+
+		if exists, _ := afero.Exists(p.FS, filePath); exists {
+			return filePath, true
+		}
+	}
+
+	return "", false
+}
+
+// readShoreConfigFile - The decoded shore config file, `nil` when the project has none.
+func readShoreConfigFile(p *project.Project) (map[string]interface{}, error) {
+	shoreConfigPath, err := shoreConfigFilePath(p)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	data, err := ReadConfigFile(p, shoreConfigPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var shoreConfig map[string]interface{}
+
+	if err := jsoniter.Unmarshal(data, &shoreConfig); err != nil {
+		return nil, err
+	}
+
+	return shoreConfig, nil
+}
+
+// loadListMergeStrategy - `merge.lists` in `shore.yml`.
+func loadListMergeStrategy(p *project.Project) (ListMergeStrategy, error) {
+	shoreConfig, err := readShoreConfigFile(p)
+
+	if err != nil {
+		return "", err
+	}
+
+	mergeConfig, _ := shoreConfig["merge"].(map[string]interface{})
+	lists, _ := mergeConfig["lists"].(string)
+
+	return ParseListMergeStrategy(lists)
+}
+
+func mergeLayers(layers []configLayer, lists ListMergeStrategy) (interface{}, error) {
+	values := make([]interface{}, 0, len(layers))
+
+	for _, layer := range layers {
+		var value interface{}
+
+		if err := jsoniter.Unmarshal(layer.data, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", layer.source, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return MergeConfigs(lists, values...), nil
+}
+
+func layerSources(layers []configLayer) string {
+	sources := make([]string, 0, len(layers))
+
+	for _, layer := range layers {
+		sources = append(sources, layer.source)
+	}
+
+	return strings.Join(sources, " + ")
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfileConfigBaseOnly(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.json"), []byte(`{"b":"b","a":"a"}`), os.ModePerm)
+
+		// Test
+		values, err := LoadProfileConfig(proj, "", "render", "default")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `{"a":"a","b":"b"}`, string(values))
+	})
+}
+
+func TestLoadProfileConfigWithProfileOverrides(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.yml"), []byte("application: app\nparams:\n  region: us-east-1\n  tags: [a, b]\n"), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.prod.yml"), []byte("params:\n  tags: [c]\n"), os.ModePerm)
+
+		// Test
+		values, err := LoadProfileConfig(proj, `{"pipeline": "deploy"}`, "render", "prod")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `{"application":"app","params":{"region":"us-east-1","tags":["c"]},"pipeline":"deploy"}`, string(values))
+	})
+}
+
+func TestLoadProfileConfigWithShoreConfigProfile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(`
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+merge:
+  lists: append
+profiles:
+  default:
+    exec: exec.yml
+  staging:
+    exec: environments/staging.yml
+`), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "exec.yml"), []byte("application: app\npipeline: deploy\nparameters:\n  regions: [us-east-1]\n"), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "environments", "staging.yml"), []byte("parameters:\n  regions: [eu-west-1]\n"), os.ModePerm)
+
+		// Test
+		defaultValues, defaultErr := LoadProfileConfig(proj, "", "exec", "default")
+		stagingValues, stagingErr := LoadProfileConfig(proj, "", "exec", "staging")
+
+		// Assert
+		assert.Nil(t, defaultErr)
+		assert.Nil(t, stagingErr)
+		assert.JSONEq(t, `{"application": "app", "pipeline": "deploy", "parameters": {"regions": ["us-east-1"]}}`, string(defaultValues))
+		assert.JSONEq(t, `{"application": "app", "pipeline": "deploy", "parameters": {"regions": ["us-east-1", "eu-west-1"]}}`, string(stagingValues))
+	})
+}
+
+func TestLoadProfileConfigFlagOnly(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Test
+		values, err := LoadProfileConfig(proj, `{"a": "a"}`, "render", "default")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, `{"a": "a"}`, string(values))
+	})
+}
+
+func TestLoadProfileConfigMissingFiles(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Test
+		_, err := LoadProfileConfig(proj, "", "render", "prod")
+
+		// Assert
+		var confErr *FileConfErr
+
+		assert.True(t, errors.As(err, &confErr))
+	})
+}
+
+func TestLoadProfileConfigSchemaErrorsWithLines(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "E2E.yml"), []byte(`application: app
+pipeline: pipeline
+tests:
+  deploy:
+    assertions:
+      Deploy:
+        expected_status: succeeded
+`), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "E2E.prod.yml"), []byte(`tests:
+  deploy:
+    assertions:
+      Deploy:
+        expected_status: suceeded
+        expected_outputs: {}
+`), os.ModePerm)
+
+		// Test
+		_, err := LoadProfileConfig(proj, "", "E2E", "prod")
+
+		// Assert
+		assert.EqualError(t, err, `invalid E2E configuration:
+	E2E.prod.yml:6: tests.deploy.assertions.Deploy.expected_outputs: unknown key
+	E2E.prod.yml:5: tests.deploy.assertions.Deploy.expected_status: unsupported value suceeded, expected one of: NOT_STARTED, RUNNING, PAUSED, SUSPENDED, SUCCEEDED, FAILED_CONTINUE, TERMINAL, CANCELED, REDIRECT, STOPPED, SKIPPED, BUFFERED`)
+	})
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/project"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// DefaultSource - The source of values shore provides when a file is missing (I.E. no `shore.yml`).
+const DefaultSource = "default"
+
+// profileConfigKeys - The configuration files of a profile, in the order they are resolved.
+var profileConfigKeys = []string{"render", "exec", "e2e"}
+
+// profileConfigFileNames - The base file name of the configuration files of a profile.
+var profileConfigFileNames = map[string]string{"render": "render", "exec": "exec", "e2e": "E2E"}
+
+// ConfigValue - A resolved configuration value and where it comes from.
+type ConfigValue struct {
+	// Key - The dotted path of the value (I.E. `render.application`).
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+	// Source - The file (relative to the project) or the flag providing the value.
+	Source string `json:"source"`
+}
+
+// ResolveConfig - Resolves the configuration shore uses for a profile and an executor config.
+//
+// Returns the values of `shore.yml` followed by the values of the profile's configuration files.
+func ResolveConfig(p *project.Project, profileName string, executorConfigName string) ([]ConfigValue, error) {
+	projectPath, err := p.GetProjectPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	shoreConfig, err := LoadShoreConfig(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	shoreSource := DefaultSource
+
+	if shoreConfigPath, err := findIndividualLocalConfig(p, "shore"); err == nil && shoreConfigPath != "" {
+		shoreSource = relativePath(projectPath, shoreConfigPath)
+	}
+
+	var values []ConfigValue
+
+	values = flattenConfig(values, "renderer", shoreConfig.Renderer, shoreSource)
+
+	for _, key := range sortedKeys(shoreConfig.Executor) {
+		if key != "config" {
+			values = flattenConfig(values, "executor."+key, shoreConfig.Executor[key], shoreSource)
+		}
+	}
+
+	if executorConfigs, ok := shoreConfig.Executor["config"].(map[string]interface{}); ok {
+		executorConfig, exists := executorConfigs[executorConfigName]
+
+		if !exists {
+			return nil, fmt.Errorf("executor config %q not found in %s, expected one of: %s", executorConfigName, shoreSource, strings.Join(sortedKeys(executorConfigs), ", "))
+		}
+
+		values = append(values, ConfigValue{Key: "executor.config", Value: executorConfig, Source: shoreSource})
+	}
+
+	profile, ok := shoreConfig.Profiles[profileName].(map[string]interface{})
+
+	// Without a shore config, profiles only exist by convention (I.E. `render.staging.yml`).
+	if !ok && shoreSource != DefaultSource {
+		return nil, fmt.Errorf("profile %q not found in %s, expected one of: %s", profileName, shoreSource, strings.Join(sortedKeys(shoreConfig.Profiles), ", "))
+	}
+
+	lists, err := loadListMergeStrategy(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range profileConfigKeys {
+		if configPath, ok := profile[key].(string); ok {
+			values = append(values, ConfigValue{Key: fmt.Sprintf("profiles.%s.%s", profileName, key), Value: relativePath(projectPath, configPath), Source: shoreSource})
+		}
+
+		layers, err := profileConfigLayers(p, profileConfigFileNames[key], profileName)
+
+		var confErr *FileConfErr
+
+		if errors.As(err, &confErr) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		merged, err := mergeLayers(layers, lists)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, layerValues(key, merged, layers)...)
+	}
+
+	return values, nil
+}
+
+// layerValues - The values of a merged config, the source of a value is the last layer providing it.
+// Values no single layer provides (I.E. appended lists) are attributed to all the layers.
+func layerValues(key string, merged interface{}, layers []configLayer) []ConfigValue {
+	values := flattenConfig(nil, key, merged, layerSources(layers))
+
+	if len(layers) == 1 {
+		return values
+	}
+
+	layersValues := make([]map[string]interface{}, 0, len(layers))
+
+	for _, layer := range layers {
+		var layerConfig interface{}
+		jsoniter.Unmarshal(layer.data, &layerConfig)
+
+		layerValues := make(map[string]interface{})
+
+		for _, value := range flattenConfig(nil, key, layerConfig, layer.source) {
+			layerValues[value.Key] = value.Value
+		}
+
+		layersValues = append(layersValues, layerValues)
+	}
+
+	for i, value := range values {
+		for j := len(layers) - 1; j >= 0; j-- {
+			if layerValue, exists := layersValues[j][value.Key]; exists && reflect.DeepEqual(layerValue, value.Value) {
+				values[i].Source = layers[j].source
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// flattenConfig - Appends the leaf values of a decoded config, keyed by their dotted path.
+func flattenConfig(values []ConfigValue, key string, value interface{}, source string) []ConfigValue {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		if len(typedValue) == 0 {
+			break
+		}
+
+		for _, childKey := range sortedKeys(typedValue) {
+			values = flattenConfig(values, joinPath(key, childKey), typedValue[childKey], source)
+		}
+
+		return values
+	case []interface{}:
+		if len(typedValue) == 0 {
+			break
+		}
+
+		for i, item := range typedValue {
+			values = flattenConfig(values, fmt.Sprintf("%s[%d]", key, i), item, source)
+		}
+
+		return values
+	}
+
+	return append(values, ConfigValue{Key: key, Value: value, Source: source})
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func relativePath(projectPath, filePath string) string {
+	relPath, err := filepath.Rel(projectPath, filePath)
+
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return filePath
+	}
+
+	return relPath
+}
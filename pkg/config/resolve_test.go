@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const testShoreConfig = `renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+  config:
+    default: ~/.spin/config
+    prod: ~/.spin/prod
+profiles:
+  default:
+    render: render.yml
+    exec: exec.yml
+  staging:
+    render: render.staging.yml
+`
+
+func TestResolveConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.staging.yml"), []byte("application: app\nparams:\n  tags: [a, b]\n"), os.ModePerm)
+
+		// Test
+		values, err := ResolveConfig(proj, "staging", "prod")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []ConfigValue{
+			{Key: "renderer.type", Value: "jsonnet", Source: "shore.yml"},
+			{Key: "executor.type", Value: "spinnaker", Source: "shore.yml"},
+			{Key: "executor.config", Value: "~/.spin/prod", Source: "shore.yml"},
+			{Key: "profiles.staging.render", Value: "render.staging.yml", Source: "shore.yml"},
+			{Key: "render.application", Value: "app", Source: "render.staging.yml"},
+			{Key: "render.params.tags[0]", Value: "a", Source: "render.staging.yml"},
+			{Key: "render.params.tags[1]", Value: "b", Source: "render.staging.yml"},
+		}, values)
+	})
+}
+
+func TestResolveConfigWithoutShoreConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "render.yml"), []byte("application: app\n"), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "exec.yml"), []byte("{}"), os.ModePerm)
+		afero.WriteFile(proj.FS, path.Join(testPath, "E2E.yml"), []byte("{}"), os.ModePerm)
+
+		// Test
+		values, err := ResolveConfig(proj, "default", "default")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, values, ConfigValue{Key: "renderer.type", Value: "jsonnet", Source: DefaultSource})
+		assert.Contains(t, values, ConfigValue{Key: "profiles.default.render", Value: "render.yml", Source: DefaultSource})
+		assert.Contains(t, values, ConfigValue{Key: "render.application", Value: "app", Source: "render.yml"})
+	})
+}
+
+func TestResolveConfigUnknownProfile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+
+		// Test
+		_, err := ResolveConfig(proj, "prod", "default")
+
+		// Assert
+		assert.EqualError(t, err, `profile "prod" not found in shore.yml, expected one of: default, staging`)
+	})
+}
+
+func TestResolveConfigUnknownExecutorConfig(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		afero.WriteFile(proj.FS, path.Join(testPath, "shore.yml"), []byte(testShoreConfig), os.ModePerm)
+
+		// Test
+		_, err := ResolveConfig(proj, "default", "staging")
+
+		// Assert
+		assert.EqualError(t, err, `executor config "staging" not found in shore.yml, expected one of: default, prod`)
+	})
+}
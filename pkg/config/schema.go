@@ -0,0 +1,415 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+//go:embed schemas/*.schema.json schemas/definitions.json
+var schemasFS embed.FS
+
+// sharedDefinitionsFile - The `definitions` available to every schema (I.E. `trigger`, shared by `exec` & `e2e`).
+const sharedDefinitionsFile = "schemas/definitions.json"
+
+var strictJSON = jsoniter.Config{
+	EscapeHTML:             true,
+	SortMapKeys:            true,
+	ValidateJsonRawMessage: true,
+	DisallowUnknownFields:  true,
+}.Froze()
+
+// DecodeStrict - Decodes a config into a struct, unknown keys are errors.
+func DecodeStrict(data []byte, v interface{}) error {
+	return strictJSON.Unmarshal(data, v)
+}
+
+// Schema - A subset of JSON Schema, enough to describe shore's configuration files.
+//
+// Supported keywords: `type`, `enum`, `minLength`, `properties`, `required`, `additionalProperties`, `items`, `anyOf`,
+// `definitions` & `$ref` (only local references - `#/definitions/<name>`, including the shared definitions).
+// `ignoreCase` (a shore extension) makes `enum` case insensitive for strings.
+type Schema struct {
+	Description string             `json:"description,omitempty"`
+	Type        []string           `json:"-"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	IgnoreCase  bool               `json:"ignoreCase,omitempty"`
+	MinLength   int                `json:"minLength,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties - `nil` allows any additional property, `Disallowed` forbids them.
+	AdditionalProperties *Schema            `json:"-"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	// Disallowed - Set for a `false` schema.
+	Disallowed bool `json:"-"`
+}
+
+// UnmarshalJSON - Handles the polymorphic keywords (`type` may be a list, schemas may be a boolean).
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var boolSchema bool
+
+	if err := jsoniter.Unmarshal(data, &boolSchema); err == nil {
+		s.Disallowed = !boolSchema
+		return nil
+	}
+
+	type schemaAlias Schema
+
+	var raw struct {
+		schemaAlias
+		Type                 interface{}         `json:"type"`
+		AdditionalProperties jsoniter.RawMessage `json:"additionalProperties"`
+	}
+
+	if err := jsoniter.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = Schema(raw.schemaAlias)
+
+	switch schemaType := raw.Type.(type) {
+	case string:
+		s.Type = []string{schemaType}
+	case []interface{}:
+		for _, t := range schemaType {
+			s.Type = append(s.Type, fmt.Sprint(t))
+		}
+	}
+
+	if len(raw.AdditionalProperties) > 0 {
+		s.AdditionalProperties = &Schema{}
+
+		if err := jsoniter.Unmarshal(raw.AdditionalProperties, s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SchemaError - A value that doesn't match its schema.
+type SchemaError struct {
+	// File - The file (relative to the project) or the flag providing the value, when known.
+	File string
+	// Line - The line of the value in `File`, 0 when unknown.
+	Line int
+	// Path - The dotted path of the value (I.E. `profiles.default.render`), empty for the root value.
+	Path    string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	var parts []string
+
+	if e.File != "" && e.Line > 0 {
+		parts = append(parts, fmt.Sprintf("%s:%d", e.File, e.Line))
+	} else if e.File != "" {
+		parts = append(parts, e.File)
+	}
+
+	if e.Path != "" {
+		parts = append(parts, e.Path)
+	}
+
+	return strings.Join(append(parts, e.Message), ": ")
+}
+
+// SchemaErrors - All the schema errors of a configuration (I.E. `exec`).
+type SchemaErrors struct {
+	Config string
+	Errors []SchemaError
+}
+
+func (e *SchemaErrors) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		messages = append(messages, "\t"+err.Error())
+	}
+
+	return fmt.Sprintf("invalid %s configuration:\n%s", e.Config, strings.Join(messages, "\n"))
+}
+
+// HasSchema - Whether shore has a schema for a configuration (I.E. `render`, `exec`, `e2e` or `shore`).
+func HasSchema(name string) bool {
+	_, err := schemasFS.ReadFile(fmt.Sprintf("schemas/%s.schema.json", name))
+	return err == nil
+}
+
+// LoadSchema - Loads an embedded schema by name (I.E. `shore`).
+func LoadSchema(name string) (*Schema, error) {
+	data, err := schemasFS.ReadFile(fmt.Sprintf("schemas/%s.schema.json", name))
+
+	if err != nil {
+		return nil, fmt.Errorf("unknown configuration schema %q", name)
+	}
+
+	var schema Schema
+
+	if err := jsoniter.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+
+	if err := addSharedDefinitions(&schema); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// addSharedDefinitions - Adds the shared definitions a schema doesn't define itself.
+func addSharedDefinitions(schema *Schema) error {
+	data, err := schemasFS.ReadFile(sharedDefinitionsFile)
+
+	if err != nil {
+		return err
+	}
+
+	var shared Schema
+
+	if err := jsoniter.Unmarshal(data, &shared); err != nil {
+		return err
+	}
+
+	if schema.Definitions == nil {
+		schema.Definitions = make(map[string]*Schema, len(shared.Definitions))
+	}
+
+	for name, definition := range shared.Definitions {
+		if _, exists := schema.Definitions[name]; !exists {
+			schema.Definitions[name] = definition
+		}
+	}
+
+	return nil
+}
+
+// Validate - Validates a decoded JSON value against the schema, returns all the errors found.
+func (s *Schema) Validate(value interface{}) []SchemaError {
+	var errors []SchemaError
+	s.validate(s, "", value, &errors)
+	return errors
+}
+
+// resolve - Follows the `$ref` of a schema, `root` holds the `definitions`.
+func (s *Schema) resolve(root *Schema) *Schema {
+	for s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		definition, exists := root.Definitions[name]
+
+		if !exists || name == s.Ref {
+			return &Schema{Disallowed: true, Description: fmt.Sprintf("unresolved reference %q", s.Ref)}
+		}
+
+		s = definition
+	}
+
+	return s
+}
+
+// types - The types a schema accepts (following `$ref` & `anyOf`), empty when any type is accepted.
+func (s *Schema) types(root *Schema) []string {
+	s = s.resolve(root)
+
+	if len(s.AnyOf) == 0 {
+		return s.Type
+	}
+
+	var types []string
+
+	for _, subSchema := range s.AnyOf {
+		subTypes := subSchema.types(root)
+
+		if len(subTypes) == 0 {
+			return nil
+		}
+
+		for _, subType := range subTypes {
+			if !contains(types, subType) {
+				types = append(types, subType)
+			}
+		}
+	}
+
+	return types
+}
+
+func (s *Schema) validate(root *Schema, path string, value interface{}, errors *[]SchemaError) {
+	s = s.resolve(root)
+
+	if s.Disallowed {
+		*errors = append(*errors, SchemaError{Path: path, Message: "is not allowed"})
+		return
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		*errors = append(*errors, SchemaError{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.Type, " or "), jsonType(value))})
+		return
+	}
+
+	if len(s.AnyOf) > 0 && !s.validateAnyOf(root, path, value, errors) {
+		return
+	}
+
+	if len(s.Enum) > 0 && !s.matchesEnum(value) {
+		allowed := make([]string, 0, len(s.Enum))
+
+		for _, enumValue := range s.Enum {
+			allowed = append(allowed, fmt.Sprintf("%v", enumValue))
+		}
+
+		*errors = append(*errors, SchemaError{Path: path, Message: fmt.Sprintf("unsupported value %v, expected one of: %s", value, strings.Join(allowed, ", "))})
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		if length := utf8.RuneCountInString(typedValue); length < s.MinLength {
+			*errors = append(*errors, SchemaError{Path: path, Message: fmt.Sprintf("expected at least %d characters, got %d", s.MinLength, length)})
+		}
+	case map[string]interface{}:
+		for _, required := range s.Required {
+			if _, exists := typedValue[required]; !exists {
+				*errors = append(*errors, SchemaError{Path: path, Message: fmt.Sprintf("missing required key %q", required)})
+			}
+		}
+
+		keys := make([]string, 0, len(typedValue))
+
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := joinPath(path, key)
+
+			if propertySchema, exists := s.Properties[key]; exists {
+				propertySchema.validate(root, keyPath, typedValue[key], errors)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Disallowed {
+				*errors = append(*errors, SchemaError{Path: keyPath, Message: "unknown key"})
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(root, keyPath, typedValue[key], errors)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range typedValue {
+				s.Items.validate(root, fmt.Sprintf("%s[%d]", path, i), item, errors)
+			}
+		}
+	}
+}
+
+// validateAnyOf - Passes when one of the `anyOf` schemas matches, otherwise reports the errors of the
+// first schema accepting the type of the value (the closest match).
+func (s *Schema) validateAnyOf(root *Schema, path string, value interface{}, errors *[]SchemaError) bool {
+	var closestErrors []SchemaError
+	closestFound := false
+
+	for _, subSchema := range s.AnyOf {
+		var subErrors []SchemaError
+		subSchema.validate(root, path, value, &subErrors)
+
+		if len(subErrors) == 0 {
+			return true
+		}
+
+		if !closestFound && matchesType(subSchema.types(root), value) {
+			closestErrors = subErrors
+			closestFound = true
+		}
+	}
+
+	if !closestFound {
+		closestErrors = []SchemaError{{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.types(root), " or "), jsonType(value))}}
+	}
+
+	*errors = append(*errors, closestErrors...)
+	return false
+}
+
+func (s *Schema) matchesType(value interface{}) bool {
+	return matchesType(s.Type, value)
+}
+
+func matchesType(types []string, value interface{}) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	valueType := jsonType(value)
+
+	for _, schemaType := range types {
+		if schemaType == valueType || (schemaType == "number" && valueType == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Schema) matchesEnum(value interface{}) bool {
+	for _, enumValue := range s.Enum {
+		if jsonType(enumValue) != jsonType(value) {
+			continue
+		}
+
+		if fmt.Sprint(enumValue) == fmt.Sprint(value) || (s.IgnoreCase && strings.EqualFold(fmt.Sprint(enumValue), fmt.Sprint(value))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonType - The JSON Schema type name of a decoded JSON value.
+func jsonType(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if typedValue == float64(int64(typedValue)) {
+			return "integer"
+		}
+
+		return "number"
+	case int, int64:
+		return "integer"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShoreSchemaValidConfig(t *testing.T) {
+	// Given
+	schema, err := LoadSchema("shore")
+	var shoreConfig interface{}
+	jsoniter.Unmarshal([]byte(`{
+		"renderer": {"type": "jsonnet"},
+		"executor": {"type": "spinnaker", "config": {"default": "~/.spin/config"}},
+		"profiles": {"default": {"render": "render.yml", "exec": "exec.yml", "e2e": "E2E.yml"}}
+	}`), &shoreConfig)
+
+	// Test
+	errors := schema.Validate(shoreConfig)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Empty(t, errors)
+}
+
+func TestShoreSchemaInvalidConfig(t *testing.T) {
+	// Given
+	schema, _ := LoadSchema("shore")
+	var shoreConfig interface{}
+	jsoniter.Unmarshal([]byte(`{
+		"renderer": {"type": "cue"},
+		"executor": {"type": "spinnaker", "config": {"default": 1}},
+		"profiles": {"default": {"render": "render.yml", "unknown": "value"}},
+		"extra": true
+	}`), &shoreConfig)
+
+	// Test
+	errors := schema.Validate(shoreConfig)
+
+	// Assert
+	assert.Equal(t, []SchemaError{
+		{Path: "executor.config.default", Message: "expected string, got integer"},
+		{Path: "extra", Message: "unknown key"},
+		{Path: "profiles.default.unknown", Message: "unknown key"},
+		{Path: "renderer.type", Message: "unsupported value cue, expected one of: jsonnet"},
+	}, errors)
+}
+
+func TestShoreSchemaMissingKeys(t *testing.T) {
+	// Given
+	schema, _ := LoadSchema("shore")
+	var shoreConfig interface{}
+	jsoniter.Unmarshal([]byte(`{"renderer": {"type": "jsonnet"}, "profiles": []}`), &shoreConfig)
+
+	// Test
+	errors := schema.Validate(shoreConfig)
+
+	// Assert
+	assert.Equal(t, []SchemaError{
+		{Path: "", Message: `missing required key "executor"`},
+		{Path: "profiles", Message: "expected object, got array"},
+	}, errors)
+}
+
+func TestShoreSchemaEmptyName(t *testing.T) {
+	// Given
+	schema, _ := LoadSchema("shore")
+	var shoreConfig interface{}
+	jsoniter.Unmarshal([]byte(`{
+		"name": "",
+		"renderer": {"type": "jsonnet"},
+		"executor": {"type": "spinnaker"},
+		"profiles": {"default": {"render": "render.yml"}}
+	}`), &shoreConfig)
+
+	// Test
+	errors := schema.Validate(shoreConfig)
+
+	// Assert
+	assert.Equal(t, []SchemaError{
+		{Path: "name", Message: "expected at least 1 characters, got 0"},
+	}, errors)
+}
+
+func TestLoadUnknownSchema(t *testing.T) {
+	// Test
+	_, err := LoadSchema("unknown")
+
+	// Assert
+	assert.EqualError(t, err, `unknown configuration schema "unknown"`)
+}
+
+func TestE2ESchemaIgnoresStatusCase(t *testing.T) {
+	// Given
+	schema, _ := LoadSchema("e2e")
+	var e2eConfig interface{}
+	jsoniter.Unmarshal([]byte(`{
+		"application": "app",
+		"pipeline": "pipeline",
+		"tests": {"deploy": {"assertions": {"Wait": {"expected_status": "succeeded"}, "Deploy": {"expected_status": "suceeded"}}}}
+	}`), &e2eConfig)
+
+	// Test
+	errors := schema.Validate(e2eConfig)
+
+	// Assert
+	assert.Equal(t, []SchemaError{{
+		Path:    "tests.deploy.assertions.Deploy.expected_status",
+		Message: "unsupported value suceeded, expected one of: NOT_STARTED, RUNNING, PAUSED, SUSPENDED, SUCCEEDED, FAILED_CONTINUE, TERMINAL, CANCELED, REDIRECT, STOPPED, SKIPPED, BUFFERED",
+	}}, errors)
+}
+
+func TestE2ESchemaStatusListAndNot(t *testing.T) {
+	// Given
+	schema, _ := LoadSchema("e2e")
+	var e2eConfig interface{}
+	jsoniter.Unmarshal([]byte(`{
+		"application": "app",
+		"pipeline": "pipeline",
+		"tests": {"deploy": {"assertions": {
+			"Wait": {"expected_status": ["succeeded", "skipped"]},
+			"Deploy": {"expected_status": {"not": "terminal"}},
+			"Check": {"expected_status": {"not": ["terminal", "canceled"]}},
+			"Bad": {"expected_status": ["succeeded", "done"]},
+			"Worse": {"expected_status": {"is": "succeeded"}},
+			"Worst": {"expected_status": 1}
+		}}}
+	}`), &e2eConfig)
+
+	// Test
+	errors := schema.Validate(e2eConfig)
+
+	// Assert
+	assert.Equal(t, []SchemaError{
+		{
+			Path:    "tests.deploy.assertions.Bad.expected_status[1]",
+			Message: "unsupported value done, expected one of: NOT_STARTED, RUNNING, PAUSED, SUSPENDED, SUCCEEDED, FAILED_CONTINUE, TERMINAL, CANCELED, REDIRECT, STOPPED, SKIPPED, BUFFERED",
+		},
+		{Path: "tests.deploy.assertions.Worse.expected_status", Message: `missing required key "not"`},
+		{Path: "tests.deploy.assertions.Worse.expected_status.is", Message: "unknown key"},
+		{Path: "tests.deploy.assertions.Worst.expected_status", Message: "expected string or array or object, got integer"},
+	}, errors)
+}
+
+func TestDecodeStrict(t *testing.T) {
+	// Given
+	var config struct {
+		Application string `json:"application"`
+	}
+
+	// Test
+	err := DecodeStrict([]byte(`{"application": "app", "aplication": "typo"}`), &config)
+
+	// Assert
+	assert.ErrorContains(t, err, "found unknown field: aplication")
+}
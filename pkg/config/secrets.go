@@ -0,0 +1,227 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// SecretsKeyEnvVar - The environment variable holding the key of the `encrypted-file` provider (base64, 32 bytes).
+const SecretsKeyEnvVar = "SHORE_SECRETS_KEY"
+
+// SecretProvider - Resolves the value of a `${<provider>:<key>}` reference in a configuration file.
+//
+// `baseDir` is the directory of the configuration file, relative paths are resolved from it.
+type SecretProvider interface {
+	Resolve(fs afero.Fs, baseDir string, key string) (string, error)
+}
+
+// SecretProviderFunc - Adapts a function into a `SecretProvider`.
+type SecretProviderFunc func(fs afero.Fs, baseDir string, key string) (string, error)
+
+// Resolve - Calls the function.
+func (f SecretProviderFunc) Resolve(fs afero.Fs, baseDir string, key string) (string, error) {
+	return f(fs, baseDir, key)
+}
+
+// registeredProvider - A provider, `Secret` providers resolve values that are masked (see `RegisterSecret`).
+type registeredProvider struct {
+	Provider SecretProvider
+	Secret   bool
+}
+
+var secretProviders = struct {
+	sync.RWMutex
+	providers map[string]registeredProvider
+}{
+	providers: map[string]registeredProvider{
+		"env":            {Provider: SecretProviderFunc(resolveEnv), Secret: true},
+		"file":           {Provider: SecretProviderFunc(resolveFile), Secret: true},
+		"encrypted-file": {Provider: SecretProviderFunc(resolveEncryptedFile), Secret: true},
+	},
+}
+
+// RegisterSecretProvider - Registers (or replaces) the provider of `${<name>:<key>}` references.
+//
+// The values it resolves are secrets, masked in logs and error messages (see `MaskSecrets`).
+func RegisterSecretProvider(name string, provider SecretProvider) {
+	secretProviders.Lock()
+	defer secretProviders.Unlock()
+
+	secretProviders.providers[name] = registeredProvider{Provider: provider, Secret: true}
+}
+
+// SecretProviderNames - The names of the registered providers, sorted.
+func SecretProviderNames() []string {
+	secretProviders.RLock()
+	defer secretProviders.RUnlock()
+
+	names := make([]string, 0, len(secretProviders.providers))
+
+	for name := range secretProviders.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func getSecretProvider(name string) (registeredProvider, bool) {
+	secretProviders.RLock()
+	defer secretProviders.RUnlock()
+
+	provider, exists := secretProviders.providers[name]
+	return provider, exists
+}
+
+// resolveEnv - `${env:NAME}`, the value of an environment variable.
+func resolveEnv(_ afero.Fs, _ string, key string) (string, error) {
+	value, exists := os.LookupEnv(key)
+
+	if !exists {
+		return "", fmt.Errorf("environment variable %q is not set", key)
+	}
+
+	return value, nil
+}
+
+// resolveFile - `${file:path}`, the content of a file (without the trailing newline).
+func resolveFile(fs afero.Fs, baseDir string, key string) (string, error) {
+	data, err := afero.ReadFile(fs, resolvePath(baseDir, key))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read %q: %w", key, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveEncryptedFile - `${encrypted-file:path}` or `${encrypted-file:path#key}`.
+//
+// The file is encrypted with `EncryptSecret`, using the key in `$SHORE_SECRETS_KEY`.
+// With `#key`, the decrypted content is a YAML/JSON object and the value of `key` is returned.
+func resolveEncryptedFile(fs afero.Fs, baseDir string, key string) (string, error) {
+	filePath, objectKey, hasObjectKey := strings.Cut(key, "#")
+
+	encrypted, err := afero.ReadFile(fs, resolvePath(baseDir, filePath))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read %q: %w", filePath, err)
+	}
+
+	decrypted, err := DecryptSecret(string(encrypted))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %q: %w", filePath, err)
+	}
+
+	if !hasObjectKey {
+		return strings.TrimRight(decrypted, "\r\n"), nil
+	}
+
+	var values map[string]interface{}
+
+	if err := yaml.Unmarshal([]byte(decrypted), &values); err != nil {
+		return "", fmt.Errorf("failed to parse %q: %w", filePath, err)
+	}
+
+	value, exists := values[objectKey]
+
+	if !exists {
+		return "", fmt.Errorf("key %q not found in %q", objectKey, filePath)
+	}
+
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+
+	data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(value)
+	return string(data), err
+}
+
+func resolvePath(baseDir, filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+
+	return filepath.Join(baseDir, filePath)
+}
+
+// EncryptSecret - Encrypts a secret for the `encrypted-file` provider (AES-256-GCM, base64 encoded).
+func EncryptSecret(plaintext string) (string, error) {
+	gcm, err := secretsCipher()
+
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+// DecryptSecret - Decrypts a secret encrypted with `EncryptSecret`.
+func DecryptSecret(encrypted string) (string, error) {
+	gcm, err := secretsCipher()
+
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encrypted))
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("the encrypted secret is too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+
+	if err != nil {
+		return "", fmt.Errorf("wrong key or corrupted secret")
+	}
+
+	return string(plaintext), nil
+}
+
+func secretsCipher() (cipher.AEAD, error) {
+	encodedKey := os.Getenv(SecretsKeyEnvVar)
+
+	if encodedKey == "" {
+		return nil, fmt.Errorf("$%s is not set", SecretsKeyEnvVar)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("$%s must be a base64 encoded 32 bytes key", SecretsKeyEnvVar)
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/base64"
+	"path"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecretsKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func TestEncryptDecryptSecret(t *testing.T) {
+	// Given
+	t.Setenv(SecretsKeyEnvVar, testSecretsKey)
+
+	// Test
+	encrypted, err := EncryptSecret("my-secret")
+	decrypted, decryptErr := DecryptSecret(encrypted)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, decryptErr)
+	assert.NotContains(t, encrypted, "my-secret")
+	assert.Equal(t, "my-secret", decrypted)
+}
+
+func TestDecryptSecretWrongKey(t *testing.T) {
+	// Given
+	t.Setenv(SecretsKeyEnvVar, testSecretsKey)
+	encrypted, _ := EncryptSecret("my-secret")
+	t.Setenv(SecretsKeyEnvVar, base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+
+	// Test
+	_, err := DecryptSecret(encrypted)
+
+	// Assert
+	assert.EqualError(t, err, "wrong key or corrupted secret")
+}
+
+func TestEncryptSecretInvalidKey(t *testing.T) {
+	// Given
+	t.Setenv(SecretsKeyEnvVar, "c2hvcnQ=")
+
+	// Test
+	_, err := EncryptSecret("my-secret")
+
+	// Assert
+	assert.EqualError(t, err, "$SHORE_SECRETS_KEY must be a base64 encoded 32 bytes key")
+}
+
+func TestReadConfigFileEncryptedFile(t *testing.T) {
+	SetupTest(t, func(t *testing.T, proj *project.Project) {
+		// Given
+		t.Setenv(SecretsKeyEnvVar, testSecretsKey)
+		encryptedToken, _ := EncryptSecret("encrypted-token-value\n")
+		encryptedObject, _ := EncryptSecret("user: encrypted-user\npassword: encrypted-password\n")
+		afero.WriteFile(proj.FS, path.Join(testPath, "token.enc"), []byte(encryptedToken), 0644)
+		afero.WriteFile(proj.FS, path.Join(testPath, "credentials.enc"), []byte(encryptedObject), 0644)
+		afero.WriteFile(proj.FS, path.Join(testPath, "E2E.yml"), []byte(`
+token: "${encrypted-file:token.enc}"
+password: "${encrypted-file:credentials.enc#password}"
+`), 0644)
+
+		// Test
+		values, err := GetFileConfig(proj, "E2E")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"token": "encrypted-token-value", "password": "encrypted-password"}`, string(values))
+	})
+}
@@ -0,0 +1,383 @@
+/*
+Package diff - Structured comparison of pipeline configurations (decoded JSON documents).
+
+Documents are compared after removing the ignored paths, arrays of objects are compared by key (I.E. the stages
+by `refId` or `name`) rather than by position. The changes are RFC 6902 (JSON patch) operations, they can be
+formatted as a unified text, a JSON patch or a summary of the changed stages.
+*/
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/backend"
+)
+
+// DefaultIgnorePaths - Spinnaker generated fields (and the ownership stamped by shore), not part of the pipeline code.
+var DefaultIgnorePaths = []string{"id", "index", "lastModifiedBy", "updateTs", "schema", backend.OwnershipKey}
+
+// DefaultArrayKeys - The keys identifying the elements of arrays of objects, by priority.
+var DefaultArrayKeys = []string{"refId", "name"}
+
+// The operations of a `Change` (RFC 6902).
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+)
+
+// Options - How documents are compared.
+type Options struct {
+	// Ignore - The paths removed from both documents before they are compared.
+	Ignore []Path
+	// ArrayKeys - Arrays of objects that all have a unique value for one of the keys are compared by key,
+	// the first key that qualifies is used. Other arrays are compared by position.
+	ArrayKeys []string
+}
+
+// DefaultOptions - Ignores `DefaultIgnorePaths` & compares arrays by `DefaultArrayKeys`.
+func DefaultOptions() Options {
+	ignore, _ := ParsePaths(DefaultIgnorePaths)
+
+	return Options{Ignore: ignore, ArrayKeys: DefaultArrayKeys}
+}
+
+// Change - A difference between two documents, an RFC 6902 operation.
+//
+// The changes of a comparison are ordered, `Pointer` & `FromPointer` are valid once the preceding changes are applied.
+type Change struct {
+	Op string
+	// Pointer - The JSON pointer of the changed value.
+	Pointer string
+	// FromPointer - The JSON pointer the value is moved from (`move`).
+	FromPointer string
+	// Path - The readable location of the change, array elements compared by key are matched by key.
+	Path Path
+	// OldValue - The replaced or removed value.
+	OldValue interface{}
+	// Value - The added or replacing value.
+	Value interface{}
+}
+
+// Compare - The changes turning `from` into `to`.
+//
+// The documents aren't modified, the ignored paths are removed from copies.
+func Compare(from, to interface{}, options Options) []Change {
+	c := &comparer{options: options}
+	c.compare(Normalize(from, options), Normalize(to, options), "", nil)
+
+	return c.changes
+}
+
+// Normalize - A copy of a document without the ignored paths.
+func Normalize(document interface{}, options Options) interface{} {
+	return Remove(copyValue(document), options.Ignore...)
+}
+
+type comparer struct {
+	options Options
+	changes []Change
+}
+
+func (c *comparer) add(change Change) {
+	c.changes = append(c.changes, change)
+}
+
+func (c *comparer) compare(from, to interface{}, pointer string, path Path) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, isMap := to.(map[string]interface{}); isMap {
+			c.compareObjects(fromValue, toValue, pointer, path)
+			return
+		}
+	case []interface{}:
+		if toValue, isArray := to.([]interface{}); isArray {
+			if key := c.arrayKey(fromValue, toValue); key != "" {
+				c.compareKeyedArrays(fromValue, toValue, key, pointer, path)
+			} else {
+				c.compareArrays(fromValue, toValue, pointer, path)
+			}
+
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		c.add(Change{Op: OpReplace, Pointer: pointer, Path: path, OldValue: from, Value: to})
+	}
+}
+
+func (c *comparer) compareObjects(from, to map[string]interface{}, pointer string, path Path) {
+	for _, key := range sortedKeys(from, to) {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		keyPointer := pointer + "/" + escapePointer(key)
+		keyPath := path.child(Segment{Kind: KeySegment, Key: key})
+
+		switch {
+		case !inTo:
+			c.add(Change{Op: OpRemove, Pointer: keyPointer, Path: keyPath, OldValue: fromValue})
+		case !inFrom:
+			c.add(Change{Op: OpAdd, Pointer: keyPointer, Path: keyPath, Value: toValue})
+		default:
+			c.compare(fromValue, toValue, keyPointer, keyPath)
+		}
+	}
+}
+
+// compareArrays - Compares by position, extra elements are removed from (or added to) the end.
+func (c *comparer) compareArrays(from, to []interface{}, pointer string, path Path) {
+	common := len(from)
+
+	if len(to) < common {
+		common = len(to)
+	}
+
+	for i := 0; i < common; i++ {
+		c.compare(from[i], to[i], indexPointer(pointer, i), path.child(Segment{Kind: IndexSegment, Index: i}))
+	}
+
+	for i := len(from) - 1; i >= common; i-- {
+		c.add(Change{Op: OpRemove, Pointer: indexPointer(pointer, i), Path: path.child(Segment{Kind: IndexSegment, Index: i}), OldValue: from[i]})
+	}
+
+	for i := common; i < len(to); i++ {
+		c.add(Change{Op: OpAdd, Pointer: indexPointer(pointer, i), Path: path.child(Segment{Kind: IndexSegment, Index: i}), Value: to[i]})
+	}
+}
+
+// compareKeyedArrays - Compares the elements by key. The removed elements are removed first, the kept elements are
+// moved to their new order and compared, then the added elements are inserted at their positions.
+func (c *comparer) compareKeyedArrays(from, to []interface{}, key string, pointer string, path Path) {
+	fromKeys := elementKeys(from, key)
+	toKeys := elementKeys(to, key)
+	fromIndexes := indexesByKey(fromKeys)
+	toIndexes := indexesByKey(toKeys)
+	elementPath := func(value string) Path {
+		return path.child(Segment{Kind: MatchSegment, Key: key, Value: value})
+	}
+
+	for i := len(fromKeys) - 1; i >= 0; i-- {
+		if _, kept := toIndexes[fromKeys[i]]; !kept {
+			c.add(Change{Op: OpRemove, Pointer: indexPointer(pointer, i), Path: elementPath(fromKeys[i]), OldValue: from[i]})
+		}
+	}
+
+	var current, wanted []string
+
+	for _, k := range fromKeys {
+		if _, kept := toIndexes[k]; kept {
+			current = append(current, k)
+		}
+	}
+
+	for _, k := range toKeys {
+		if _, kept := fromIndexes[k]; kept {
+			wanted = append(wanted, k)
+		}
+	}
+
+	for i, k := range wanted {
+		j := i
+
+		for current[j] != k {
+			j++
+		}
+
+		if j != i {
+			c.add(Change{Op: OpMove, Pointer: indexPointer(pointer, i), FromPointer: indexPointer(pointer, j), Path: elementPath(k)})
+			current = append(current[:j], current[j+1:]...)
+			current = append(current[:i], append([]string{k}, current[i:]...)...)
+		}
+	}
+
+	for i, k := range wanted {
+		c.compare(from[fromIndexes[k]], to[toIndexes[k]], indexPointer(pointer, i), elementPath(k))
+	}
+
+	for i, k := range toKeys {
+		if _, kept := fromIndexes[k]; !kept {
+			c.add(Change{Op: OpAdd, Pointer: indexPointer(pointer, i), Path: elementPath(k), Value: to[i]})
+		}
+	}
+}
+
+// arrayKey - The first of the array keys that identifies every element of both arrays, "" when there is none.
+func (c *comparer) arrayKey(from, to []interface{}) string {
+	if len(from) == 0 || len(to) == 0 {
+		return ""
+	}
+
+	for _, key := range c.options.ArrayKeys {
+		if identifiesElements(from, key) && identifiesElements(to, key) {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// identifiesElements - Whether every element is an object with a unique scalar value for the key.
+func identifiesElements(elements []interface{}, key string) bool {
+	seen := make(map[string]bool, len(elements))
+
+	for _, element := range elements {
+		elementMap, isMap := element.(map[string]interface{})
+
+		if !isMap {
+			return false
+		}
+
+		switch value := elementMap[key].(type) {
+		case string, float64, bool:
+			k := fmt.Sprint(value)
+
+			if seen[k] {
+				return false
+			}
+
+			seen[k] = true
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func elementKeys(elements []interface{}, key string) []string {
+	keys := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		keys = append(keys, fmt.Sprint(element.(map[string]interface{})[key]))
+	}
+
+	return keys
+}
+
+func indexesByKey(keys []string) map[string]int {
+	indexes := make(map[string]int, len(keys))
+
+	for i, k := range keys {
+		indexes[k] = i
+	}
+
+	return indexes
+}
+
+// Align - A copy of `from` whose arrays compared by key are ordered like their counterparts in `to`,
+// elements that aren't part of `to` are kept last. Makes positional comparisons (I.E. a console diff) readable.
+func Align(from, to interface{}, options Options) interface{} {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, isMap := to.(map[string]interface{})
+		aligned := make(map[string]interface{}, len(fromValue))
+
+		for key, value := range fromValue {
+			if isMap {
+				aligned[key] = Align(value, toValue[key], options)
+			} else {
+				aligned[key] = copyValue(value)
+			}
+		}
+
+		return aligned
+	case []interface{}:
+		toValue, isArray := to.([]interface{})
+		c := &comparer{options: options}
+		key := ""
+
+		if isArray {
+			key = c.arrayKey(fromValue, toValue)
+		}
+
+		aligned := make([]interface{}, 0, len(fromValue))
+
+		if key == "" {
+			for i, element := range fromValue {
+				if isArray && i < len(toValue) {
+					aligned = append(aligned, Align(element, toValue[i], options))
+				} else {
+					aligned = append(aligned, copyValue(element))
+				}
+			}
+
+			return aligned
+		}
+
+		fromIndexes := indexesByKey(elementKeys(fromValue, key))
+		toKeys := elementKeys(toValue, key)
+		toIndexes := indexesByKey(toKeys)
+
+		for _, k := range toKeys {
+			if i, exists := fromIndexes[k]; exists {
+				aligned = append(aligned, Align(fromValue[i], toValue[toIndexes[k]], options))
+			}
+		}
+
+		for i, k := range elementKeys(fromValue, key) {
+			if _, exists := toIndexes[k]; !exists {
+				aligned = append(aligned, copyValue(fromValue[i]))
+			}
+		}
+
+		return aligned
+	}
+
+	return from
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// escapePointer - Escapes a key for a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func indexPointer(pointer string, index int) string {
+	return pointer + "/" + strconv.Itoa(index)
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+
+		for key, element := range v {
+			copied[key] = copyValue(element)
+		}
+
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+
+		for i, element := range v {
+			copied[i] = copyValue(element)
+		}
+
+		return copied
+	}
+
+	return value
+}
@@ -0,0 +1,213 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// applyPatch - A minimal RFC 6902 implementation, validates the pointers of the changes.
+func applyPatch(t *testing.T, document interface{}, changes []Change) interface{} {
+	var root interface{} = map[string]interface{}{"": copyValue(document)}
+
+	for _, change := range changes {
+		if change.Op == OpMove {
+			value := removePointer(t, root, change.FromPointer)
+			addPointer(t, root, change.Pointer, value)
+			continue
+		}
+
+		switch change.Op {
+		case OpRemove:
+			removePointer(t, root, change.Pointer)
+		case OpAdd:
+			addPointer(t, root, change.Pointer, change.Value)
+		case OpReplace:
+			removePointer(t, root, change.Pointer)
+			addPointer(t, root, change.Pointer, change.Value)
+		}
+	}
+
+	return root.(map[string]interface{})[""]
+}
+
+// pointerParent - The parent container of a pointer and the last token, the root is wrapped under "".
+func pointerParent(t *testing.T, root interface{}, pointer string) (interface{}, func(interface{}), string) {
+	tokens := strings.Split("/"+pointer, "/")[1:]
+	tokens[0] = ""
+	parent := root
+	set := func(interface{}) {}
+
+	for _, token := range tokens[:len(tokens)-1] {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			key := token
+			parent = p[key]
+			set = func(value interface{}) { p[key] = value }
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			assert.Nil(t, err)
+			parent = p[index]
+			set = func(value interface{}) { p[index] = value }
+		}
+	}
+
+	return parent, set, tokens[len(tokens)-1]
+}
+
+func removePointer(t *testing.T, root interface{}, pointer string) interface{} {
+	parent, set, token := pointerParent(t, root, pointer)
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		value, exists := p[token]
+		assert.True(t, exists, fmt.Sprintf("%s doesn't exist", pointer))
+		delete(p, token)
+		return value
+	case []interface{}:
+		index, _ := strconv.Atoi(token)
+		value := p[index]
+		set(append(append([]interface{}{}, p[:index]...), p[index+1:]...))
+		return value
+	}
+
+	t.Fatalf("invalid pointer %s", pointer)
+	return nil
+}
+
+func addPointer(t *testing.T, root interface{}, pointer string, value interface{}) {
+	parent, set, token := pointerParent(t, root, pointer)
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[token] = value
+	case []interface{}:
+		index, _ := strconv.Atoi(token)
+		assert.LessOrEqual(t, index, len(p))
+		set(append(append(append([]interface{}{}, p[:index]...), value), p[index:]...))
+	default:
+		t.Fatalf("invalid pointer %s", pointer)
+	}
+}
+
+func TestCompareObjects(t *testing.T) {
+	// Given
+	from := decode(`{"name": "Deploy", "description": "old", "keepWaitingPipelines": false, "id": "1234"}`)
+	to := decode(`{"name": "Deploy", "description": "new", "limitConcurrent": true}`)
+
+	// Test
+	changes := Compare(from, to, DefaultOptions())
+
+	// Assert
+	assert.Equal(t, []Change{
+		{Op: OpReplace, Pointer: "/description", Path: Path{{Kind: KeySegment, Key: "description"}}, OldValue: "old", Value: "new"},
+		{Op: OpRemove, Pointer: "/keepWaitingPipelines", Path: Path{{Kind: KeySegment, Key: "keepWaitingPipelines"}}, OldValue: false},
+		{Op: OpAdd, Pointer: "/limitConcurrent", Path: Path{{Kind: KeySegment, Key: "limitConcurrent"}}, Value: true},
+	}, changes)
+	assert.Equal(t, "1234", from.(map[string]interface{})["id"])
+}
+
+func TestCompareEqual(t *testing.T) {
+	// Given
+	from := decode(`{"name": "Deploy", "updateTs": "1", "stages": [{"name": "Wait", "refId": "1"}]}`)
+	to := decode(`{"name": "Deploy", "stages": [{"name": "Wait", "refId": "1"}]}`)
+
+	// Test
+	changes := Compare(from, to, DefaultOptions())
+
+	// Assert
+	assert.Empty(t, changes)
+}
+
+func TestCompareKeyedArrays(t *testing.T) {
+	// Given
+	from := decode(`{"stages": [
+		{"name": "Wait", "refId": "1"},
+		{"name": "Old", "refId": "2"},
+		{"name": "Deploy", "refId": "3", "waitTime": 30},
+		{"name": "Verify", "refId": "4"}
+	]}`)
+	to := decode(`{"stages": [
+		{"name": "Verify", "refId": "4"},
+		{"name": "New", "refId": "5"},
+		{"name": "Wait", "refId": "1"},
+		{"name": "Deploy", "refId": "3", "waitTime": 60}
+	]}`)
+
+	// Test
+	changes := Compare(from, to, DefaultOptions())
+
+	// Assert
+	var paths []string
+
+	for _, change := range changes {
+		paths = append(paths, change.Op+" "+change.Path.String())
+	}
+
+	assert.Equal(t, []string{
+		"remove stages[refId=2]",
+		"move stages[refId=4]",
+		"replace stages[refId=3].waitTime",
+		"add stages[refId=5]",
+	}, paths)
+	assert.Equal(t, Normalize(to, DefaultOptions()), applyPatch(t, Normalize(from, DefaultOptions()), changes))
+}
+
+func TestCompareKeyedArraysIgnoredRefID(t *testing.T) {
+	// Given
+	options := DefaultOptions()
+	refIDs, _ := ParsePath("stages[*].refId")
+	options.Ignore = append(options.Ignore, refIDs)
+
+	from := decode(`{"stages": [{"name": "Wait", "refId": "1"}, {"name": "Deploy", "refId": "2"}]}`)
+	to := decode(`{"stages": [{"name": "Deploy", "refId": "1"}, {"name": "Wait", "refId": "2"}]}`)
+
+	// Test
+	changes := Compare(from, to, options)
+
+	// Assert
+	assert.Len(t, changes, 1)
+	assert.Equal(t, OpMove, changes[0].Op)
+	assert.Equal(t, "stages[name=Deploy]", changes[0].Path.String())
+	assert.Equal(t, "/stages/1", changes[0].FromPointer)
+	assert.Equal(t, "/stages/0", changes[0].Pointer)
+}
+
+func TestCompareArraysByPosition(t *testing.T) {
+	// Given
+	from := decode(`{"tags": ["a", "b", "c"], "notifications": [{"type": "slack"}]}`)
+	to := decode(`{"tags": ["a", "x"], "notifications": [{"type": "email"}, {"type": "slack"}]}`)
+
+	// Test
+	changes := Compare(from, to, DefaultOptions())
+
+	// Assert
+	var paths []string
+
+	for _, change := range changes {
+		paths = append(paths, change.Op+" "+change.Path.String())
+	}
+
+	assert.Equal(t, []string{
+		"replace notifications[0].type",
+		"add notifications[1]",
+		"replace tags[1]",
+		"remove tags[2]",
+	}, paths)
+	assert.Equal(t, to, applyPatch(t, from, changes))
+}
+
+func TestAlign(t *testing.T) {
+	// Given
+	from := decode(`{"stages": [{"name": "Wait"}, {"name": "Old"}, {"name": "Deploy", "stages": [{"name": "b"}, {"name": "a"}]}]}`)
+	to := decode(`{"stages": [{"name": "Deploy", "stages": [{"name": "a"}, {"name": "b"}]}, {"name": "Wait"}]}`)
+
+	// Test
+	aligned := Align(from, to, DefaultOptions())
+
+	// Assert
+	assert.Equal(t, decode(`{"stages": [{"name": "Deploy", "stages": [{"name": "a"}, {"name": "b"}]}, {"name": "Wait"}, {"name": "Old"}]}`), aligned)
+}
@@ -0,0 +1,223 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// The output formats of the changes.
+const (
+	FormatUnified   = "unified"
+	FormatJSONPatch = "json-patch"
+	FormatSummary   = "summary"
+)
+
+// Formats - The supported output formats.
+var Formats = []string{FormatUnified, FormatJSONPatch, FormatSummary}
+
+var formatJSON = jsoniter.Config{EscapeHTML: false, SortMapKeys: true}.Froze()
+
+// Format - Formats the changes, see `Formats`.
+func Format(changes []Change, format string) (string, error) {
+	switch format {
+	case FormatUnified:
+		return Unified(changes, "current", "desired"), nil
+	case FormatJSONPatch:
+		patch, err := JSONPatch(changes)
+		return string(patch), err
+	case FormatSummary:
+		return Summarize(changes).String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported diff output %q, expected one of: %s", format, strings.Join(Formats, ", "))
+}
+
+// Unified - The changes as a unified text, a hunk per change headed by its path.
+//
+// Removed values are prefixed with `-`, added values with `+`, moved elements are noted with `~`.
+func Unified(changes []Change, fromLabel, toLabel string) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromLabel, toLabel)
+
+	for _, change := range changes {
+		fmt.Fprintf(&builder, "@@ %s @@\n", change.Path)
+
+		switch change.Op {
+		case OpMove:
+			fmt.Fprintf(&builder, "~ moved from %s to %s\n", change.FromPointer, change.Pointer)
+		case OpRemove:
+			writeValueLines(&builder, "-", change.OldValue)
+		case OpAdd:
+			writeValueLines(&builder, "+", change.Value)
+		case OpReplace:
+			writeValueLines(&builder, "-", change.OldValue)
+			writeValueLines(&builder, "+", change.Value)
+		}
+	}
+
+	return builder.String()
+}
+
+func writeValueLines(builder *strings.Builder, prefix string, value interface{}) {
+	data, _ := formatJSON.MarshalIndent(value, "", "  ")
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(builder, "%s %s\n", prefix, line)
+	}
+}
+
+// patchOperation - An RFC 6902 operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	From  string      `json:"from,omitempty"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// JSONPatch - The changes as an RFC 6902 JSON patch, applying it to the compared (normalized) `from` document results in `to`.
+func JSONPatch(changes []Change) ([]byte, error) {
+	return formatJSON.MarshalIndent(patchOperations(changes), "", "  ")
+}
+
+// JSONPatches - The changes of several documents as one JSON document, the JSON patch (see `JSONPatch`) of each document by its key.
+func JSONPatches(changes map[string][]Change) ([]byte, error) {
+	patches := make(map[string][]interface{}, len(changes))
+
+	for key, documentChanges := range changes {
+		patches[key] = patchOperations(documentChanges)
+	}
+
+	return formatJSON.MarshalIndent(patches, "", "  ")
+}
+
+// patchOperations - The RFC 6902 operations of the changes.
+func patchOperations(changes []Change) []interface{} {
+	operations := make([]interface{}, 0, len(changes))
+
+	for _, change := range changes {
+		switch change.Op {
+		case OpAdd, OpReplace:
+			// `value` is required, even when it is `null`.
+			operations = append(operations, map[string]interface{}{"op": change.Op, "path": change.Pointer, "value": change.Value})
+		case OpMove:
+			operations = append(operations, patchOperation{Op: change.Op, From: change.FromPointer, Path: change.Pointer})
+		default:
+			operations = append(operations, patchOperation{Op: change.Op, Path: change.Pointer})
+		}
+	}
+
+	return operations
+}
+
+// StageChange - How a stage changed.
+type StageChange struct {
+	// Stage - The stage identifier, its key (I.E. `name=Deploy`) or its position (I.E. `[2]`).
+	Stage string
+	// Changes - `added`, `removed`, `moved` and/or `modified`.
+	Changes []string
+	// Fields - The modified fields of the stage.
+	Fields []string
+}
+
+// Summary - The changed stages, and the other changed top level fields.
+type Summary struct {
+	Stages []StageChange
+	Fields []string
+}
+
+// Summarize - Groups the changes by stage.
+func Summarize(changes []Change) Summary {
+	var summary Summary
+	stageIndexes := make(map[string]int)
+
+	for _, change := range changes {
+		if len(change.Path) == 0 {
+			summary.Fields = appendUnique(summary.Fields, "(root)")
+			continue
+		}
+
+		field := change.Path[0].Key
+
+		if field != "stages" || len(change.Path) < 2 {
+			summary.Fields = appendUnique(summary.Fields, field)
+			continue
+		}
+
+		stage := change.Path[1].String()
+
+		if change.Path[1].Kind == MatchSegment {
+			stage = change.Path[1].Key + "=" + change.Path[1].Value
+		}
+
+		index, exists := stageIndexes[stage]
+
+		if !exists {
+			index = len(summary.Stages)
+			stageIndexes[stage] = index
+			summary.Stages = append(summary.Stages, StageChange{Stage: stage})
+		}
+
+		stageChange := &summary.Stages[index]
+
+		switch {
+		case len(change.Path) > 2:
+			stageChange.Changes = appendUnique(stageChange.Changes, "modified")
+			stageChange.Fields = appendUnique(stageChange.Fields, change.Path[2].String())
+		case change.Op == OpAdd:
+			stageChange.Changes = appendUnique(stageChange.Changes, "added")
+		case change.Op == OpRemove:
+			stageChange.Changes = appendUnique(stageChange.Changes, "removed")
+		case change.Op == OpMove:
+			stageChange.Changes = appendUnique(stageChange.Changes, "moved")
+		default:
+			stageChange.Changes = appendUnique(stageChange.Changes, "replaced")
+		}
+	}
+
+	return summary
+}
+
+func (s Summary) String() string {
+	if len(s.Stages) == 0 && len(s.Fields) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	if len(s.Stages) > 0 {
+		builder.WriteString("Changed stages:\n")
+
+		for _, stage := range s.Stages {
+			fmt.Fprintf(&builder, "  %s: %s", stage.Stage, strings.Join(stage.Changes, ", "))
+
+			if len(stage.Fields) > 0 {
+				fmt.Fprintf(&builder, " (%s)", strings.Join(stage.Fields, ", "))
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	if len(s.Fields) > 0 {
+		fmt.Fprintf(&builder, "Changed fields: %s\n", strings.Join(s.Fields, ", "))
+	}
+
+	return builder.String()
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var formatFrom = decode(`{"description": "old", "stages": [{"name": "Wait", "waitTime": 30}, {"name": "Old"}]}`)
+var formatTo = decode(`{"description": "new", "stages": [{"name": "Wait", "waitTime": 60}, {"name": "New", "type": "wait"}]}`)
+
+func TestUnified(t *testing.T) {
+	// Test
+	unified := Unified(Compare(formatFrom, formatTo, DefaultOptions()), "current", "desired")
+
+	// Assert
+	assert.Equal(t, `--- current
++++ desired
+@@ description @@
+- "old"
++ "new"
+@@ stages[name=Old] @@
+- {
+-   "name": "Old"
+- }
+@@ stages[name=Wait].waitTime @@
+- 30
++ 60
+@@ stages[name=New] @@
++ {
++   "name": "New",
++   "type": "wait"
++ }
+`, unified)
+	assert.Equal(t, "", Unified(nil, "current", "desired"))
+}
+
+func TestJSONPatch(t *testing.T) {
+	// Test
+	patch, err := JSONPatch(Compare(formatFrom, formatTo, DefaultOptions()))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"op": "replace", "path": "/description", "value": "new"},
+		{"op": "remove", "path": "/stages/1"},
+		{"op": "replace", "path": "/stages/0/waitTime", "value": 60},
+		{"op": "add", "path": "/stages/1", "value": {"name": "New", "type": "wait"}}
+	]`, string(patch))
+}
+
+func TestJSONPatches(t *testing.T) {
+	// Test
+	patches, err := JSONPatches(map[string][]Change{
+		"app/changed":   Compare(formatFrom, formatTo, DefaultOptions()),
+		"app/unchanged": nil,
+	})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"app/changed": [
+			{"op": "replace", "path": "/description", "value": "new"},
+			{"op": "remove", "path": "/stages/1"},
+			{"op": "replace", "path": "/stages/0/waitTime", "value": 60},
+			{"op": "add", "path": "/stages/1", "value": {"name": "New", "type": "wait"}}
+		],
+		"app/unchanged": []
+	}`, string(patches))
+}
+
+func TestSummarize(t *testing.T) {
+	// Test
+	summary := Summarize(Compare(formatFrom, formatTo, DefaultOptions()))
+
+	// Assert
+	assert.Equal(t, Summary{
+		Stages: []StageChange{
+			{Stage: "name=Old", Changes: []string{"removed"}},
+			{Stage: "name=Wait", Changes: []string{"modified"}, Fields: []string{"waitTime"}},
+			{Stage: "name=New", Changes: []string{"added"}},
+		},
+		Fields: []string{"description"},
+	}, summary)
+	assert.Equal(t, `Changed stages:
+  name=Old: removed
+  name=Wait: modified (waitTime)
+  name=New: added
+Changed fields: description
+`, summary.String())
+}
+
+func TestFormatUnsupported(t *testing.T) {
+	// Test
+	_, err := Format(nil, "html")
+
+	// Assert
+	assert.EqualError(t, err, `unsupported diff output "html", expected one of: unified, json-patch, summary`)
+}
@@ -0,0 +1,230 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SegmentKind - The kind of a path segment.
+type SegmentKind int
+
+const (
+	// KeySegment - An object key (`stages`), `*` matches any key.
+	KeySegment SegmentKind = iota
+	// IndexSegment - An array index (`[0]`), `[*]` matches any index.
+	IndexSegment
+	// MatchSegment - The elements of an array matching a key (`[name=Deploy]`).
+	MatchSegment
+)
+
+// Segment - A part of a `Path`.
+type Segment struct {
+	Kind SegmentKind
+	// Key - The object key (`KeySegment`) or the element key (`MatchSegment`).
+	Key string
+	// Index - The array index (`IndexSegment`), -1 matches any index.
+	Index int
+	// Value - The element key value (`MatchSegment`).
+	Value string
+}
+
+func (s Segment) String() string {
+	switch s.Kind {
+	case IndexSegment:
+		if s.Index < 0 {
+			return "[*]"
+		}
+
+		return fmt.Sprintf("[%d]", s.Index)
+	case MatchSegment:
+		return fmt.Sprintf("[%s=%s]", s.Key, s.Value)
+	}
+
+	return s.Key
+}
+
+// Path - A location in a JSON document, I.E. `triggers[*].lastSuccessfulExecution` or `stages[name=Deploy].waitTime`.
+type Path []Segment
+
+func (p Path) String() string {
+	var builder strings.Builder
+
+	for i, segment := range p {
+		if segment.Kind == KeySegment && i > 0 {
+			builder.WriteString(".")
+		}
+
+		builder.WriteString(segment.String())
+	}
+
+	return builder.String()
+}
+
+// child - A copy of the path with another segment, paths share their backing arrays otherwise.
+func (p Path) child(segment Segment) Path {
+	return append(append(make(Path, 0, len(p)+1), p...), segment)
+}
+
+// ParsePath - Parses a path, keys are separated by dots and followed by any number of `[index]`, `[*]` or `[key=value]`.
+func ParsePath(path string) (Path, error) {
+	var parsed Path
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("invalid path %q: the path is empty", path)
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		brackets := ""
+
+		if i := strings.Index(part, "["); i >= 0 {
+			key = part[:i]
+			brackets = part[i:]
+		}
+
+		if key == "" && (len(parsed) == 0 || brackets == "") {
+			return nil, fmt.Errorf("invalid path %q: empty key", path)
+		}
+
+		if key != "" {
+			parsed = append(parsed, Segment{Kind: KeySegment, Key: key})
+		}
+
+		for brackets != "" {
+			end := strings.Index(brackets, "]")
+
+			if !strings.HasPrefix(brackets, "[") || end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unbalanced brackets", path)
+			}
+
+			segment, err := parseBracket(brackets[1:end])
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+
+			parsed = append(parsed, segment)
+			brackets = brackets[end+1:]
+		}
+	}
+
+	return parsed, nil
+}
+
+// ParsePaths - Parses a list of paths, see `ParsePath`.
+func ParsePaths(paths []string) ([]Path, error) {
+	parsed := make([]Path, 0, len(paths))
+
+	for _, path := range paths {
+		p, err := ParsePath(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, p)
+	}
+
+	return parsed, nil
+}
+
+func parseBracket(content string) (Segment, error) {
+	if content == "*" {
+		return Segment{Kind: IndexSegment, Index: -1}, nil
+	}
+
+	if key, value, isMatch := strings.Cut(content, "="); isMatch {
+		if key == "" {
+			return Segment{}, fmt.Errorf("empty element key in [%s]", content)
+		}
+
+		return Segment{Kind: MatchSegment, Key: key, Value: value}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+
+	if err != nil || index < 0 {
+		return Segment{}, fmt.Errorf("invalid index [%s], expected a number, * or key=value", content)
+	}
+
+	return Segment{Kind: IndexSegment, Index: index}, nil
+}
+
+// Remove - Removes the values matching the paths from a document (in place), the document is returned.
+func Remove(document interface{}, paths ...Path) interface{} {
+	for _, path := range paths {
+		if len(path) > 0 {
+			document = remove(document, path)
+		}
+	}
+
+	return document
+}
+
+func remove(value interface{}, path Path) interface{} {
+	segment := path[0]
+	last := len(path) == 1
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if segment.Kind != KeySegment {
+			return v
+		}
+
+		for key := range v {
+			if segment.Key != "*" && segment.Key != key {
+				continue
+			}
+
+			if last {
+				delete(v, key)
+			} else {
+				v[key] = remove(v[key], path[1:])
+			}
+		}
+
+		return v
+	case []interface{}:
+		if segment.Kind == KeySegment {
+			return v
+		}
+
+		kept := make([]interface{}, 0, len(v))
+
+		for i, element := range v {
+			if !segment.matchesElement(i, element) {
+				kept = append(kept, element)
+				continue
+			}
+
+			if !last {
+				kept = append(kept, remove(element, path[1:]))
+			}
+		}
+
+		return kept
+	}
+
+	return value
+}
+
+// matchesElement - Whether an array element is matched by an index or match segment.
+func (s Segment) matchesElement(index int, element interface{}) bool {
+	switch s.Kind {
+	case IndexSegment:
+		return s.Index < 0 || s.Index == index
+	case MatchSegment:
+		elementMap, isMap := element.(map[string]interface{})
+
+		if !isMap {
+			return false
+		}
+
+		value, exists := elementMap[s.Key]
+
+		return exists && fmt.Sprint(value) == s.Value
+	}
+
+	return false
+}
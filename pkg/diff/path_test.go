@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(document string) interface{} {
+	var value interface{}
+	jsoniter.UnmarshalFromString(document, &value)
+	return value
+}
+
+func TestParsePath(t *testing.T) {
+	// Test
+	path, err := ParsePath("triggers[*].lastSuccessfulExecution")
+	matchPath, matchErr := ParsePath("stages[name=Deploy].notifications[0]")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, matchErr)
+	assert.Equal(t, Path{
+		{Kind: KeySegment, Key: "triggers"},
+		{Kind: IndexSegment, Index: -1},
+		{Kind: KeySegment, Key: "lastSuccessfulExecution"},
+	}, path)
+	assert.Equal(t, "triggers[*].lastSuccessfulExecution", path.String())
+	assert.Equal(t, "stages[name=Deploy].notifications[0]", matchPath.String())
+}
+
+func TestParsePathInvalid(t *testing.T) {
+	// Test
+	_, emptyErr := ParsePath("")
+	_, emptyKeyErr := ParsePath("stages..name")
+	_, bracketsErr := ParsePath("stages[*.name")
+	_, indexErr := ParsePath("stages[first]")
+
+	// Assert
+	assert.EqualError(t, emptyErr, `invalid path "": the path is empty`)
+	assert.EqualError(t, emptyKeyErr, `invalid path "stages..name": empty key`)
+	assert.EqualError(t, bracketsErr, `invalid path "stages[*.name": unbalanced brackets`)
+	assert.EqualError(t, indexErr, `invalid path "stages[first]": invalid index [first], expected a number, * or key=value`)
+}
+
+func TestRemove(t *testing.T) {
+	// Given
+	document := decode(`{
+		"id": "1234",
+		"triggers": [{"type": "jenkins", "lastSuccessfulExecution": {"id": 1}}, {"type": "cron"}],
+		"stages": [{"name": "Wait", "refId": "1"}, {"name": "Deploy", "refId": "2", "waitTime": 30}]
+	}`)
+	paths, err := ParsePaths([]string{"id", "triggers[*].lastSuccessfulExecution", "stages[*].refId", "stages[name=Deploy].waitTime", "missing.key"})
+
+	// Test
+	removed := Remove(document, paths...)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, decode(`{
+		"triggers": [{"type": "jenkins"}, {"type": "cron"}],
+		"stages": [{"name": "Wait"}, {"name": "Deploy"}]
+	}`), removed)
+}
+
+func TestRemoveArrayElements(t *testing.T) {
+	// Given
+	document := decode(`{"stages": [{"name": "Wait"}, {"name": "Deploy"}, {"name": "Verify"}], "tags": ["a", "b"]}`)
+	paths, _ := ParsePaths([]string{"stages[name=Deploy]", "tags[0]"})
+
+	// Test
+	removed := Remove(document, paths...)
+
+	// Assert
+	assert.Equal(t, decode(`{"stages": [{"name": "Wait"}, {"name": "Verify"}], "tags": ["b"]}`), removed)
+}
@@ -5,7 +5,9 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -13,19 +15,34 @@ import (
 	v1 "github.com/jsonnet-bundler/jsonnet-bundler/spec/v1"
 	v1Dependencies "github.com/jsonnet-bundler/jsonnet-bundler/spec/v1/deps"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 )
 
 // Holds the content of the whole `templates` directory
 //
-//go:embed templates/*
+//go:embed all:templates
 var templates embed.FS
 
+// Renderers - The renderers a project can be initialized with.
+var Renderers = []string{"Jsonnet"}
+
+// Backends - The backends a project can be initialized with.
+var Backends = []string{"Spinnaker"}
+
 // ShoreProjectInit - Common data structure to initialize a shore project.
 type ShoreProjectInit struct {
 	projectName string
 	Renderer    string
 	Backend     string
 	Libraries   []string
+	// Template - The name of an embedded template (see `Templates`) or a local template directory, defaults to `DefaultTemplate`.
+	Template string
+	// Force - Overwrite existing files.
+	Force bool
+	// Profiles - Additional profiles (I.E. environments) to create, besides the default profile.
+	Profiles []string
+	// Files - Generated files (I.E. the code of an imported pipeline), written as-is on top of the template files.
+	Files map[string]string
 }
 
 // NewShoreProjectInit - Creates a ShoreProjectInit
@@ -70,7 +87,8 @@ Init - Initializes a shore project
 
 	This all or nothing method wraps all the necessary required steps to prep a shore project for a user.
 
-	Creates the following files:
+	Creates the files of the selected template, the default template creates the following files:
+	- shore.yml
 	- README.md
 	- E2E.yml
 	- render.yml
@@ -79,41 +97,80 @@ Init - Initializes a shore project
 	- main.pipeline.jsonnet
 	- .gitignore
 	- tests/example_test.libsonnet
+	- cleanup/render.yml
+	- cleanup/exec.yml
+	- cleanup/cleanup.pipeline.jsonnet
+
+	`shore.yml` holds the renderer, the executor and the profiles of the project (`default` and the additional `Profiles`).
+	The configuration files of additional profiles are created as a copy of the default ones (I.E. `render.staging.yml`).
+
+	Refuses to overwrite existing files, unless `Force` is set.
 
-	Does not run jsonnent-bundler install (`jb install`).
+	Does not install the shared libraries (`shore deps install`).
 */
 func (pInit *ProjectInitialize) Init(shoreInit ShoreProjectInit) error {
-	if err := pInit.createFileFromTemplate("README.md", shoreInit); err != nil {
-		return err
-	}
-	if err := pInit.createFileFromTemplate("E2E.yml", shoreInit); err != nil {
-		return err
-	}
-	if err := pInit.createFileFromTemplate("render.yml", shoreInit); err != nil {
-		return err
-	}
-	if err := pInit.createFileFromTemplate("exec.yml", shoreInit); err != nil {
-		return err
+	templateName := shoreInit.Template
+
+	if templateName == "" {
+		templateName = DefaultTemplate
 	}
-	if err := pInit.createFileFromTemplate("main.pipeline.jsonnet", shoreInit); err != nil {
+
+	templateFiles, err := pInit.templateFiles(templateName)
+
+	if err != nil {
 		return err
 	}
-	if err := pInit.createFileFromTemplate(".gitignore", shoreInit); err != nil {
-		return err
+
+	for fileName, content := range shoreInit.Files {
+		templateFiles[fileName] = content
 	}
 
-	if err := pInit.createFileFromTemplate("tests/example_test.libsonnet", shoreInit); err != nil {
-		return err
+	// Templates may provide their own shore config.
+	if _, exists := templateFiles[ShoreConfigFileName]; !exists {
+		configFiles, err := shoreConfigFiles(shoreInit, templateFiles)
+
+		if err != nil {
+			return err
+		}
+
+		for fileName, content := range configFiles {
+			templateFiles[fileName] = content
+		}
 	}
 
-	if err := pInit.createFileFromTemplate("cleanup/render.yml", shoreInit); err != nil {
-		return err
+	fileNames := make([]string, 0, len(templateFiles)+1)
+
+	for fileName := range templateFiles {
+		fileNames = append(fileNames, fileName)
 	}
-	if err := pInit.createFileFromTemplate("cleanup/exec.yml", shoreInit); err != nil {
-		return err
+
+	fileNames = append(fileNames, "jsonnetfile.json")
+	sort.Strings(fileNames)
+
+	if !shoreInit.Force {
+		if err := pInit.checkExistingFiles(fileNames); err != nil {
+			return err
+		}
 	}
-	if err := pInit.createFileFromTemplate("cleanup/cleanup.pipeline.jsonnet", shoreInit); err != nil {
-		return err
+
+	for _, fileName := range fileNames {
+		content, isTemplate := templateFiles[fileName]
+
+		if !isTemplate {
+			continue
+		}
+
+		if generated, isGenerated := shoreInit.Files[fileName]; isGenerated {
+			if err := pInit.Project.WriteFile(fileName, generated); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := pInit.createFileFromTemplate(fileName, content, shoreInit); err != nil {
+			return err
+		}
 	}
 
 	jsonnetDependencies := make(map[string]v1Dependencies.Dependency)
@@ -133,31 +190,45 @@ func (pInit *ProjectInitialize) Init(shoreInit ShoreProjectInit) error {
 		return err
 	}
 
-	pInit.Project.WriteFile("jsonnetfile.json", string(jsonnetFileBytes))
-
-	return nil
+	return pInit.Project.WriteFile("jsonnetfile.json", string(jsonnetFileBytes))
 }
 
-func (pInit *ProjectInitialize) createFileFromTemplate(fileName string, shoreInit ShoreProjectInit) error {
-	templateContent, err := templates.ReadFile(fmt.Sprintf("templates/%s", fileName))
+// checkExistingFiles - Fails when any of the files already exist in the project.
+func (pInit *ProjectInitialize) checkExistingFiles(fileNames []string) error {
+	projectPath, err := pInit.Project.GetProjectPath()
+
 	if err != nil {
 		return err
 	}
 
-	t, err := template.New(fileName).Parse(string(templateContent))
+	var existingFiles []string
+
+	for _, fileName := range fileNames {
+		if exists, _ := afero.Exists(pInit.Project.FS, filepath.Join(projectPath, fileName)); exists {
+			existingFiles = append(existingFiles, fileName)
+		}
+	}
+
+	if len(existingFiles) > 0 {
+		return fmt.Errorf("refusing to overwrite existing files (use --force to overwrite them): %s", strings.Join(existingFiles, ", "))
+	}
+
+	return nil
+}
+
+func (pInit *ProjectInitialize) createFileFromTemplate(fileName, templateContent string, shoreInit ShoreProjectInit) error {
+	t, err := template.New(fileName).Parse(templateContent)
 
 	if err != nil {
-		return err
+		return fmt.Errorf("the template file %q isn't a valid Go template (write `{{ \"{{\" }}` for a literal `{{`): %w", fileName, err)
 	}
 
 	var tpl bytes.Buffer
 	if err := t.Execute(&tpl, shoreInit); err != nil {
-		return err
+		return fmt.Errorf("failed to execute the template file %q: %w", fileName, err)
 	}
 
-	pInit.Project.WriteFile(fileName, tpl.String())
-
-	return nil
+	return pInit.Project.WriteFile(fileName, tpl.String())
 }
 
 // IsValidGoVersion - Checks if the currently installed response from Golang binary version command has GOMOD support
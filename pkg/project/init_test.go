@@ -175,3 +175,252 @@ func TestMessyAppName(t *testing.T) {
 	assert.Equal(t, "MyProject", appNameMessy)
 
 }
+
+func newTestProjectInitialize(localFs afero.Fs) project.ProjectInitialize {
+	return project.ProjectInitialize{
+		Log: Logger,
+		Project: project.Project{
+			FS:   localFs,
+			Log:  Logger,
+			Path: "/tmp/test/",
+		},
+	}
+}
+
+func TestInitWithTemplateSuccess(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Template = "nested"
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	mainExists, _ := afero.Exists(localFs, "/tmp/test/main.pipeline.jsonnet")
+	readmeExists, _ := afero.Exists(localFs, "/tmp/test/README.md")
+	gitIgnoreExists, _ := afero.Exists(localFs, "/tmp/test/.gitignore")
+	cleanupPipelineExists, _ := afero.Exists(localFs, "/tmp/test/cleanup/cleanup.pipeline.jsonnet")
+	mainContent, _ := afero.ReadFile(localFs, "/tmp/test/main.pipeline.jsonnet")
+
+	assert.Nil(t, err)
+	assert.True(t, mainExists)
+	assert.True(t, readmeExists)
+	assert.True(t, gitIgnoreExists)
+	assert.False(t, cleanupPipelineExists)
+	assert.Contains(t, string(mainContent), `"type": "pipeline"`)
+}
+
+func TestInitWithLocalTemplateSuccess(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	afero.WriteFile(localFs, "/templates/my-template/main.pipeline.jsonnet", []byte(`// {{ .ProjectName }}`), 0644)
+	afero.WriteFile(localFs, "/templates/my-template/lib/stages.libsonnet", []byte(`{}`), 0644)
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Template = "/templates/my-template"
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	mainContent, _ := afero.ReadFile(localFs, "/tmp/test/main.pipeline.jsonnet")
+	libExists, _ := afero.Exists(localFs, "/tmp/test/lib/stages.libsonnet")
+	readmeExists, _ := afero.Exists(localFs, "/tmp/test/README.md")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "// my-project", string(mainContent))
+	assert.True(t, libExists)
+	assert.False(t, readmeExists)
+}
+
+func TestInitWithInvalidLocalTemplateFails(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	afero.WriteFile(localFs, "/templates/my-template/main.pipeline.jsonnet", []byte(`{ name: '{{ broken' }`), 0644)
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Template = "/templates/my-template"
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	assert.ErrorContains(t, err, "the template file \"main.pipeline.jsonnet\" isn't a valid Go template (write `{{ \"{{\" }}` for a literal `{{`)")
+}
+
+func TestInitWithUnknownTemplateFails(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Template = "unknown"
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	assert.EqualError(t, err, `unknown template "unknown", expected one of cleanup, kube-job, nested, simple or a local template directory`)
+}
+
+func TestInitRefusesToOverwriteFiles(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	afero.WriteFile(localFs, "/tmp/test/render.yml", []byte("existing"), 0644)
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	renderContent, _ := afero.ReadFile(localFs, "/tmp/test/render.yml")
+	mainExists, _ := afero.Exists(localFs, "/tmp/test/main.pipeline.jsonnet")
+
+	assert.EqualError(t, err, "refusing to overwrite existing files (use --force to overwrite them): render.yml")
+	assert.Equal(t, "existing", string(renderContent))
+	assert.False(t, mainExists)
+}
+
+func TestInitForceOverwritesFiles(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	afero.WriteFile(localFs, "/tmp/test/render.yml", []byte("existing"), 0644)
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Force = true
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	renderContent, _ := afero.ReadFile(localFs, "/tmp/test/render.yml")
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(renderContent), `application: "my-project"`)
+}
+
+func TestInitCreatesShoreConfig(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	shoreConfig, _ := afero.ReadFile(localFs, "/tmp/test/shore.yml")
+
+	assert.Nil(t, err)
+	assert.Equal(t, `name: my-project
+renderer:
+  type: jsonnet
+executor:
+  type: spinnaker
+  config:
+    default: ~/.spin/config
+profiles:
+  default:
+    render: render.yml
+    exec: exec.yml
+    e2e: E2E.yml
+`, string(shoreConfig))
+}
+
+func TestInitCreatesAdditionalProfiles(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Profiles = []string{"staging", "prod"}
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	shoreConfig, _ := afero.ReadFile(localFs, "/tmp/test/shore.yml")
+	renderContent, _ := afero.ReadFile(localFs, "/tmp/test/render.yml")
+	stagingRenderContent, _ := afero.ReadFile(localFs, "/tmp/test/render.staging.yml")
+	prodExecExists, _ := afero.Exists(localFs, "/tmp/test/exec.prod.yml")
+	prodE2EExists, _ := afero.Exists(localFs, "/tmp/test/E2E.prod.yml")
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(shoreConfig), `  staging:
+    render: render.staging.yml
+    exec: exec.staging.yml
+    e2e: E2E.staging.yml
+  prod:
+    render: render.prod.yml
+    exec: exec.prod.yml
+    e2e: E2E.prod.yml
+`)
+	assert.Equal(t, string(renderContent), string(stagingRenderContent))
+	assert.True(t, prodExecExists)
+	assert.True(t, prodE2EExists)
+}
+
+func TestInitWithInvalidProfileFails(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Profiles = []string{"my/profile"}
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	shoreConfigExists, _ := afero.Exists(localFs, "/tmp/test/shore.yml")
+
+	assert.EqualError(t, err, "invalid profile name \"my/profile\", profile names may only contain letters, digits, `-` and `_` (and can't be \"default\")")
+	assert.False(t, shoreConfigExists)
+}
+
+func TestInitRefusesToOverwriteShoreConfig(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+	afero.WriteFile(localFs, "/tmp/test/shore.yml", []byte("existing"), 0644)
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	assert.EqualError(t, err, "refusing to overwrite existing files (use --force to overwrite them): shore.yml")
+}
+
+func TestInitWithGeneratedFiles(t *testing.T) {
+	// Given
+	localFs := afero.NewMemMapFs()
+
+	init := project.NewShoreProjectInit("my-project", "Jsonnet", "Spinnaker", []string{})
+	init.Template = project.ImportTemplate
+	init.Files = map[string]string{
+		"main.pipeline.jsonnet": "function(params={}) ({ name: '{{ not a template }}' })\n",
+		"render.yml":            "application: app\npipeline: pipeline\n",
+	}
+	pInit := newTestProjectInitialize(localFs)
+
+	// Test
+	err := pInit.Init(init)
+
+	// Assert
+	mainContent, _ := afero.ReadFile(localFs, "/tmp/test/main.pipeline.jsonnet")
+	shoreConfig, _ := afero.ReadFile(localFs, "/tmp/test/shore.yml")
+	readmeExists, _ := afero.Exists(localFs, "/tmp/test/README.md")
+	testExists, _ := afero.Exists(localFs, "/tmp/test/tests/example_test.libsonnet")
+
+	assert.Nil(t, err)
+	assert.Equal(t, init.Files["main.pipeline.jsonnet"], string(mainContent))
+	assert.Contains(t, string(shoreConfig), "render: render.yml")
+	assert.True(t, readmeExists)
+	assert.False(t, testExists)
+}
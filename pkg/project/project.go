@@ -54,6 +54,17 @@ func (p *Project) GetProjectPath() (string, error) {
 	return projectPath, err
 }
 
+// GetProjectName - The name of the project (the name of its directory).
+func (p *Project) GetProjectName() (string, error) {
+	projectPath, err := p.GetProjectPath()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(projectPath), nil
+}
+
 // WriteFile write a file to the project path
 func (p *Project) WriteFile(fileName, data string) error {
 	// This method causes a Marshal->UnMarshal
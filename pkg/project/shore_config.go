@@ -0,0 +1,106 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ShoreConfigFileName - The project's shore config, created by `Init`.
+const ShoreConfigFileName = "shore.yml"
+
+// DefaultProfile - The profile used when none is selected.
+const DefaultProfile = "default"
+
+// DefaultExecutorConfig - The Spinnaker (`spin`) config used by the default executor config.
+const DefaultExecutorConfig = "~/.spin/config"
+
+// profileConfigFiles - The configuration files of a profile (profile key -> default file name).
+var profileConfigFiles = []struct {
+	key      string
+	fileName string
+}{
+	{"render", "render.yml"},
+	{"exec", "exec.yml"},
+	{"e2e", "E2E.yml"},
+}
+
+var profileNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// shoreConfigFiles - The shore config of the project and the configuration files of its additional profiles.
+//
+// Additional profiles start as a copy of the default profile files (I.E. `render.staging.yml`).
+func shoreConfigFiles(shoreInit ShoreProjectInit, templateFiles map[string]string) (map[string]string, error) {
+	files := make(map[string]string)
+
+	profiles := yaml.MapSlice{{Key: DefaultProfile, Value: profileConfig(templateFiles, "")}}
+
+	for _, profile := range shoreInit.Profiles {
+		if !profileNameRegexp.MatchString(profile) || profile == DefaultProfile {
+			return nil, fmt.Errorf("invalid profile name %q, profile names may only contain letters, digits, `-` and `_` (and can't be %q)", profile, DefaultProfile)
+		}
+
+		for _, configFile := range profileConfigFiles {
+			if content, exists := templateFiles[configFile.fileName]; exists {
+				files[profileFileName(configFile.fileName, profile)] = content
+			}
+		}
+
+		profiles = append(profiles, yaml.MapItem{Key: profile, Value: profileConfig(templateFiles, profile)})
+	}
+
+	// The name identifies the pipelines saved by the project (see `backend.Ownership`).
+	shoreConfig := yaml.MapSlice{
+		{Key: "name", Value: shoreInit.ProjectName()},
+		{Key: "renderer", Value: yaml.MapSlice{{Key: "type", Value: strings.ToLower(orDefault(shoreInit.Renderer, Renderers[0]))}}},
+		{Key: "executor", Value: yaml.MapSlice{
+			{Key: "type", Value: strings.ToLower(orDefault(shoreInit.Backend, Backends[0]))},
+			{Key: "config", Value: yaml.MapSlice{{Key: DefaultProfile, Value: DefaultExecutorConfig}}},
+		}},
+		{Key: "profiles", Value: profiles},
+	}
+
+	shoreConfigBytes, err := yaml.Marshal(shoreConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files[ShoreConfigFileName] = string(shoreConfigBytes)
+
+	return files, nil
+}
+
+// profileConfig - The configuration files of a profile, only the files created by the template are referenced.
+func profileConfig(templateFiles map[string]string, profile string) yaml.MapSlice {
+	config := yaml.MapSlice{}
+
+	for _, configFile := range profileConfigFiles {
+		if _, exists := templateFiles[configFile.fileName]; exists {
+			config = append(config, yaml.MapItem{Key: configFile.key, Value: profileFileName(configFile.fileName, profile)})
+		}
+	}
+
+	return config
+}
+
+// profileFileName - I.E. `render.yml` -> `render.staging.yml`
+func profileFileName(fileName, profile string) string {
+	if profile == "" {
+		return fileName
+	}
+
+	extensionIndex := strings.LastIndex(fileName, ".")
+
+	return fmt.Sprintf("%s.%s%s", fileName[:extensionIndex], profile, fileName[extensionIndex:])
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,123 @@
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// DefaultTemplate - The template used when none is selected (a pipeline and its cleanup pipeline).
+const DefaultTemplate = "cleanup"
+
+// Templates - The embedded project templates.
+// Each template is a list of layers (directories under `templates/`), applied in order.
+var Templates = map[string][]string{
+	"simple":   {"common", "simple"},
+	"cleanup":  {"common", "simple", "cleanup"},
+	"nested":   {"common", "nested"},
+	"kube-job": {"common", "kube-job"},
+}
+
+// ImportTemplate - The template of imported projects (`shore import`), the pipeline code & the configuration
+// files are generated (`ShoreProjectInit.Files`). Not listed in `Templates`, it has no pipeline of its own.
+const ImportTemplate = "import"
+
+var importTemplateLayers = []string{"common"}
+
+// TemplateNames - The names of the embedded project templates, sorted.
+func TemplateNames() []string {
+	names := make([]string, 0, len(Templates))
+
+	for name := range Templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// templateFiles - Reads the files of a template, keyed by their path relative to the project.
+//
+// The template is either the name of an embedded template or a local template directory.
+// The files of both are Go templates (`text/template`) executed with the `ShoreProjectInit` (I.E. `{{ .ProjectName }}`),
+// a literal `{{` is written `{{ "{{" }}`.
+func (pInit *ProjectInitialize) templateFiles(templateName string) (map[string]string, error) {
+	files := make(map[string]string)
+
+	layers, exists := Templates[templateName]
+
+	if templateName == ImportTemplate {
+		layers, exists = importTemplateLayers, true
+	}
+
+	if exists {
+		for _, layer := range layers {
+			layerPath := path.Join("templates", layer)
+
+			err := fs.WalkDir(templates, layerPath, func(filePath string, entry fs.DirEntry, err error) error {
+				if err != nil || entry.IsDir() {
+					return err
+				}
+
+				content, err := templates.ReadFile(filePath)
+
+				if err != nil {
+					return err
+				}
+
+				files[strings.TrimPrefix(filePath, layerPath+"/")] = string(content)
+
+				return nil
+			})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return files, nil
+	}
+
+	if isDir, _ := afero.DirExists(pInit.Project.FS, templateName); !isDir {
+		return nil, fmt.Errorf("unknown template %q, expected one of %s or a local template directory", templateName, strings.Join(TemplateNames(), ", "))
+	}
+
+	err := afero.Walk(pInit.Project.FS, templateName, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		content, err := afero.ReadFile(pInit.Project.FS, filePath)
+
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(templateName, filePath)
+
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(relativePath)] = string(content)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("the template directory %q is empty", templateName)
+	}
+
+	return files, nil
+}
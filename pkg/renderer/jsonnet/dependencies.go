@@ -0,0 +1,208 @@
+package jsonnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/jsonnet-bundler/jsonnet-bundler/pkg"
+	"github.com/jsonnet-bundler/jsonnet-bundler/pkg/jsonnetfile"
+	jbV1 "github.com/jsonnet-bundler/jsonnet-bundler/spec/v1"
+	"github.com/jsonnet-bundler/jsonnet-bundler/spec/v1/deps"
+	"github.com/spf13/afero"
+)
+
+// JsonnetLockFileName - The Jsonnet-Bundler lock file, holding the resolved version of each dependency.
+const JsonnetLockFileName string = jsonnetfile.LockFile
+
+// InstallDependencies - Installs the dependencies of `jsonnetfile.json` into `vendor/`, the same way `jb install` does.
+func (j *Jsonnet) InstallDependencies(projectPath string) error {
+	return j.ensureDependencies(projectPath, func(locks map[string]deps.Dependency) map[string]deps.Dependency {
+		return locks
+	})
+}
+
+// UpdateDependencies - Updates the dependencies of `jsonnetfile.json` to their latest versions, the same way `jb update` does.
+func (j *Jsonnet) UpdateDependencies(projectPath string, names []string) error {
+	return j.ensureDependencies(projectPath, func(locks map[string]deps.Dependency) map[string]deps.Dependency {
+		if len(names) == 0 {
+			return make(map[string]deps.Dependency)
+		}
+
+		for _, name := range names {
+			delete(locks, name)
+		}
+
+		return locks
+	})
+}
+
+// ListDependencies - Lists the direct dependencies of `jsonnetfile.json` with their locked version.
+func (j *Jsonnet) ListDependencies(projectPath string) ([]renderer.Dependency, error) {
+	jbFile, err := j.loadJsonnetBundlerFile(projectPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lockFile, err := j.loadJsonnetBundlerLockFile(projectPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	dependencies := make([]renderer.Dependency, 0, len(jbFile.Dependencies))
+
+	for name, dependency := range jbFile.Dependencies {
+		listed := renderer.Dependency{Name: name, Version: dependency.Version}
+
+		if locked, exists := lockFile.Dependencies[name]; exists {
+			listed.LockedVersion = locked.Version
+
+			// Local dependencies are never versioned.
+			if locked.Source.LocalSource != nil {
+				listed.LockedVersion = locked.Source.LocalSource.Directory
+			}
+		}
+
+		dependencies = append(dependencies, listed)
+	}
+
+	sort.Slice(dependencies, func(i, k int) bool {
+		return dependencies[i].Name < dependencies[k].Name
+	})
+
+	return dependencies, nil
+}
+
+// ensureDependencies - Installs the dependencies and writes the lock file.
+//
+// `jsonnetfile.json` & the lock file are read & written through the renderer filesystem (the same as `ListDependencies`),
+// Jsonnet-Bundler only installs into the OS filesystem.
+// Local dependencies are passed with absolute paths (Jsonnet-Bundler resolves them relative to the working directory),
+// the lock file keeps the paths of `jsonnetfile.json`.
+func (j *Jsonnet) ensureDependencies(projectPath string, locksToKeep func(map[string]deps.Dependency) map[string]deps.Dependency) error {
+	absProjectPath, err := filepath.Abs(projectPath)
+
+	if err != nil {
+		return err
+	}
+
+	jbFile, err := j.loadJsonnetBundlerFile(projectPath)
+
+	if err != nil {
+		return err
+	}
+
+	lockFile, err := j.loadJsonnetBundlerLockFile(projectPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// A dependency that changed in `jsonnetfile.json` is installed again, the same way `jb install <uri>` does.
+	for name, locked := range lockFile.Dependencies {
+		if dependency, exists := jbFile.Dependencies[name]; exists && lockOutdated(dependency, locked) {
+			delete(lockFile.Dependencies, name)
+		}
+	}
+
+	localDirectories := make(map[string]string)
+
+	for name, dependency := range jbFile.Dependencies {
+		if dependency.Source.LocalSource == nil || filepath.IsAbs(dependency.Source.LocalSource.Directory) {
+			continue
+		}
+
+		localDirectories[name] = dependency.Source.LocalSource.Directory
+		dependency.Source.LocalSource = &deps.Local{Directory: filepath.Join(absProjectPath, dependency.Source.LocalSource.Directory)}
+		jbFile.Dependencies[name] = dependency
+	}
+
+	vendorPath := filepath.Join(absProjectPath, ShareLibsPath)
+
+	if err := j.fs.MkdirAll(filepath.Join(vendorPath, ".tmp"), os.ModePerm); err != nil {
+		return err
+	}
+
+	j.log.Info("Installing the dependencies of ", filepath.Join(projectPath, JsonnetFileName))
+	locks, err := pkg.Ensure(jbFile, vendorPath, locksToKeep(lockFile.Dependencies))
+
+	if err != nil {
+		return fmt.Errorf("failed to install the dependencies: %w", err)
+	}
+
+	for name, directory := range localDirectories {
+		if locked, exists := locks[name]; exists && locked.Source.LocalSource != nil {
+			locked.Source.LocalSource = &deps.Local{Directory: directory}
+			locks[name] = locked
+		}
+	}
+
+	lockBytes, err := jsoniter.ConfigCompatibleWithStandardLibrary.MarshalIndent(jbV1.JsonnetFile{Dependencies: locks}, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(j.fs, filepath.Join(projectPath, JsonnetLockFileName), append(lockBytes, '\n'), 0644)
+}
+
+func (j *Jsonnet) loadJsonnetBundlerLockFile(path string) (jbV1.JsonnetFile, error) {
+	bytes, err := afero.ReadFile(j.fs, filepath.Join(path, JsonnetLockFileName))
+
+	if err != nil {
+		return jbV1.New(), err
+	}
+
+	return jsonnetfile.Unmarshal(bytes)
+}
+
+// outdatedDependencies - The dependencies of `jsonnetfile.json` missing from the lock file, or locked at another version or source.
+func (j *Jsonnet) outdatedDependencies(projectPath string, jbFile jbV1.JsonnetFile) ([]string, error) {
+	if len(jbFile.Dependencies) == 0 {
+		return nil, nil
+	}
+
+	lockFile, err := j.loadJsonnetBundlerLockFile(projectPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var outdated []string
+
+	for name, dependency := range jbFile.Dependencies {
+		if locked, exists := lockFile.Dependencies[name]; !exists || lockOutdated(dependency, locked) {
+			outdated = append(outdated, name)
+		}
+	}
+
+	sort.Strings(outdated)
+
+	return outdated, nil
+}
+
+// commitRegexp - A (possibly abbreviated) git commit, the version Jsonnet-Bundler locks git dependencies at.
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// lockOutdated - Checks if a locked dependency doesn't match `jsonnetfile.json` anymore.
+//
+// Git dependencies are locked at a commit, branches & tags can't be compared offline, only commits are.
+func lockOutdated(dependency deps.Dependency, locked deps.Dependency) bool {
+	if !reflect.DeepEqual(dependency.Source, locked.Source) {
+		return true
+	}
+
+	if dependency.Source.LocalSource != nil || dependency.Version == locked.Version {
+		return false
+	}
+
+	return commitRegexp.MatchString(dependency.Version) && !strings.HasPrefix(locked.Version, dependency.Version)
+}
@@ -2,6 +2,11 @@ package jsonnet
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
 )
 
 // SharedLibErr - Custom error for the custom implementation of JSONNET shared libraries.
@@ -14,3 +19,214 @@ type SharedLibErr struct {
 func (s SharedLibErr) Error() string {
 	return fmt.Sprintf("name: %s, path: %s, err: %v", s.Require, s.Path, s.Err)
 }
+
+func (s SharedLibErr) Unwrap() error {
+	return s.Err
+}
+
+// RenderError - A render failure, parsed from the Jsonnet error into a user friendly format.
+//
+// File paths are relative to the project when the file is part of the project.
+type RenderError struct {
+	Message string
+	File    string
+	Line    int
+	Column  int
+	// Snippet - The source line(s) of the failure with the failing part underlined.
+	Snippet string
+	// ImportChain - The files leading to the failure, starting with the main file.
+	ImportChain []string
+	Hints       []string
+	Err         error
+}
+
+func (e *RenderError) Error() string {
+	var builder strings.Builder
+
+	if e.File != "" && e.Line > 0 {
+		fmt.Fprintf(&builder, "%s:%d:%d ", e.File, e.Line, e.Column)
+	} else if e.File != "" {
+		fmt.Fprintf(&builder, "%s ", e.File)
+	}
+
+	builder.WriteString(e.Message)
+	builder.WriteString("\n")
+
+	if e.Snippet != "" {
+		builder.WriteString("\n")
+		builder.WriteString(e.Snippet)
+	}
+
+	if len(e.ImportChain) > 1 {
+		builder.WriteString("\nImport chain:\n")
+
+		for i, file := range e.ImportChain {
+			if i == 0 {
+				fmt.Fprintf(&builder, "\t%s\n", file)
+			} else {
+				fmt.Fprintf(&builder, "\t-> %s\n", file)
+			}
+		}
+	}
+
+	for _, hint := range e.Hints {
+		fmt.Fprintf(&builder, "\nHint: %s\n", hint)
+	}
+
+	return builder.String()
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// staticError - The exported surface of go-jsonnet's (internal) static errors (I.E. parse errors).
+type staticError interface {
+	Error() string
+	Loc() ast.LocationRange
+}
+
+// errorFormatter - A `jsonnet.ErrorFormatter` keeping the typed error of the evaluation.
+//
+// `jsonnet.VM` only returns the formatted error string, the typed error is required to build a `RenderError`.
+type errorFormatter struct {
+	jsonnet.ErrorFormatter
+	err error
+}
+
+func newErrorFormatter(vm *jsonnet.VM) *errorFormatter {
+	return &errorFormatter{ErrorFormatter: vm.ErrorFormatter}
+}
+
+func (f *errorFormatter) Format(err error) string {
+	f.err = err
+	return f.ErrorFormatter.Format(err)
+}
+
+// newRenderError - Builds a `RenderError` out of a Jsonnet evaluation error.
+func newRenderError(projectPath string, err error, importer *FileImporter) *RenderError {
+	renderErr := &RenderError{Message: err.Error(), Err: err}
+
+	var loc *ast.LocationRange
+
+	switch typedErr := err.(type) {
+	case jsonnet.RuntimeError:
+		renderErr.Message = typedErr.Msg
+
+		locations := make([]ast.LocationRange, 0, len(typedErr.StackTrace))
+
+		for _, frame := range typedErr.StackTrace {
+			locations = append(locations, frame.Loc)
+		}
+
+		for i := range locations {
+			if isSourceFile(&locations[i]) {
+				loc = &locations[i]
+				break
+			}
+		}
+
+	case staticError:
+		location := typedErr.Loc()
+		loc = &location
+		renderErr.Message = strings.TrimPrefix(typedErr.Error(), location.String()+" ")
+	}
+
+	if importer.importErr != nil {
+		renderErr.Message = importer.importErr.Err.Error()
+		renderErr.Err = *importer.importErr
+	}
+
+	if loc != nil {
+		renderErr.File = relativePath(projectPath, locFileName(loc))
+
+		for _, file := range importer.importChain(locFileName(loc)) {
+			renderErr.ImportChain = append(renderErr.ImportChain, relativePath(projectPath, file))
+		}
+
+		if loc.IsSet() {
+			renderErr.Line = loc.Begin.Line
+			renderErr.Column = loc.Begin.Column
+			renderErr.Snippet = snippet(loc)
+		}
+	}
+
+	return renderErr
+}
+
+func locFileName(loc *ast.LocationRange) string {
+	if loc.File != nil && loc.File.DiagnosticFileName != "" {
+		return string(loc.File.DiagnosticFileName)
+	}
+
+	return loc.FileName
+}
+
+// isSourceFile - Locations in the std library or in a TLA/extVar aren't useful to the user.
+func isSourceFile(loc *ast.LocationRange) bool {
+	fileName := locFileName(loc)
+	return loc.IsSet() && fileName != "" && !strings.HasPrefix(fileName, "<")
+}
+
+func relativePath(projectPath, fileName string) string {
+	if !filepath.IsAbs(fileName) {
+		return fileName
+	}
+
+	relPath, err := filepath.Rel(projectPath, fileName)
+
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return fileName
+	}
+
+	return relPath
+}
+
+// snippet - The source lines of a location, with the location underlined.
+func snippet(loc *ast.LocationRange) string {
+	if loc.File == nil || loc.Begin.Line < 1 || loc.Begin.Line > len(loc.File.Lines) {
+		return ""
+	}
+
+	line := strings.TrimRight(loc.File.Lines[loc.Begin.Line-1], "\n")
+
+	if strings.TrimSpace(line) == "" {
+		return ""
+	}
+	lineNumber := fmt.Sprintf("%d", loc.Begin.Line)
+	gutter := strings.Repeat(" ", len(lineNumber))
+
+	begin := loc.Begin.Column - 1
+	end := len(line)
+
+	if loc.End.Line == loc.Begin.Line && loc.End.Column-1 > begin {
+		end = loc.End.Column - 1
+	}
+
+	if begin < 0 || begin > len(line) {
+		return fmt.Sprintf("%s | %s\n", lineNumber, line)
+	}
+
+	if end > len(line) {
+		end = len(line)
+	}
+
+	// Keep tabs so the underline is aligned with the code.
+	var underline strings.Builder
+
+	for _, char := range line[:begin] {
+		if char == '\t' {
+			underline.WriteRune('\t')
+		} else {
+			underline.WriteRune(' ')
+		}
+	}
+
+	if end <= begin {
+		end = begin + 1
+	}
+
+	underline.WriteString(strings.Repeat("^", end-begin))
+
+	return fmt.Sprintf("%s | %s\n%s | %s\n", lineNumber, line, gutter, underline.String())
+}
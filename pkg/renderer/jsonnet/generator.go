@@ -0,0 +1,283 @@
+package jsonnet
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/google/go-jsonnet/formatter"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SpinLibJsonnet - The import path of the `spin-lib-jsonnet` library used by the generated code.
+const SpinLibJsonnet = "spin-lib-jsonnet"
+
+var generatorJSON = jsoniter.Config{EscapeHTML: false}.Froze()
+
+// constructor - A `spin-lib-jsonnet` object the generated code extends (I.E. `stage.WaitStage`).
+type constructor struct {
+	// library - The library file (and local variable) the constructor is exposed by.
+	library string
+	name    string
+	// required - The keys that must be (non-empty) strings for the constructor to be used.
+	required []string
+	// defaults - The values set by the constructor, omitted from the generated code.
+	defaults map[string]interface{}
+}
+
+var stageDefaults = map[string]interface{}{"refId": "", "requisiteStageRefIds": []interface{}{}}
+
+// stageConstructors - The recognized stages, by type.
+var stageConstructors = map[string]constructor{
+	"wait": {"stage", "WaitStage", []string{"name"}, withDefaults(stageDefaults, map[string]interface{}{
+		"type":     "wait",
+		"waitTime": float64(1),
+	})},
+	"manualJudgment": {"stage", "ManualJudgmentStage", []string{"name"}, withDefaults(stageDefaults, map[string]interface{}{
+		"type":           "manualJudgment",
+		"instructions":   "",
+		"judgmentInputs": []interface{}{},
+	})},
+	"webhook": {"stage", "WebhookStage", []string{"name", "url", "method"}, withDefaults(stageDefaults, map[string]interface{}{
+		"type": "webhook",
+	})},
+	"pipeline": {"stage", "PipelineStage", []string{"name", "application", "pipeline"}, withDefaults(stageDefaults, map[string]interface{}{
+		"type":               "pipeline",
+		"failPipeline":       true,
+		"pipelineParameters": map[string]interface{}{},
+		"waitForCompletion":  true,
+	})},
+}
+
+var triggerDefaults = map[string]interface{}{"enabled": true, "expectedArtifactIds": []interface{}{}}
+
+// triggerConstructors - The recognized triggers, by type.
+var triggerConstructors = map[string]constructor{
+	"jenkins": {"trigger", "JenkinsTrigger", []string{"master", "job"}, withDefaults(triggerDefaults, map[string]interface{}{
+		"type":         "jenkins",
+		"propertyFile": "",
+	})},
+	"pipeline": {"trigger", "PipelineTrigger", []string{"application", "pipeline"}, withDefaults(triggerDefaults, map[string]interface{}{
+		"type":   "pipeline",
+		"status": []interface{}{"successful"},
+	})},
+	"webhook": {"trigger", "WebhookTrigger", []string{"source"}, withDefaults(triggerDefaults, map[string]interface{}{
+		"type":               "webhook",
+		"payloadConstraints": map[string]interface{}{},
+	})},
+}
+
+// leadingKeys - Keys emitted first (in this order), the other keys are sorted.
+var leadingKeys = []string{"application", "name", "type", "refId", "requisiteStageRefIds"}
+
+// Generate - Generates the main file of a project rendering `pipeline`.
+//
+// Stages & triggers recognized by `spin-lib-jsonnet` extend its constructors (I.E. `stage.WaitStage`),
+// the values set by the constructors are omitted and the ones the pipeline doesn't set are hidden. Anything else is kept as-is.
+func (j *Jsonnet) Generate(pipeline map[string]interface{}) (map[string]string, error) {
+	mainFile := RenderFiles[renderer.MainFileName]
+	g := &generator{libraries: make(map[string]bool)}
+
+	body := g.object("", pipeline, nil, []field{
+		{key: "application", expression: "params.application"},
+		{key: "name", expression: "params.pipeline"},
+	})
+
+	var source strings.Builder
+
+	source.WriteString("/**\n    Imported from an existing pipeline by `shore import`.\n**/\n\n")
+
+	libraries := make([]string, 0, len(g.libraries))
+
+	for library := range g.libraries {
+		libraries = append(libraries, library)
+	}
+
+	sort.Strings(libraries)
+
+	for _, library := range libraries {
+		fmt.Fprintf(&source, "local %s = import '%s/%s.libsonnet';\n", library, SpinLibJsonnet, library)
+	}
+
+	if len(libraries) > 0 {
+		source.WriteString("\n")
+	}
+
+	fmt.Fprintf(&source, "function(params={}) (\n%s\n)\n", body)
+
+	formatted, err := formatter.Format(mainFile, source.String(), formatter.DefaultOptions())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{mainFile: formatted}, nil
+}
+
+// field - A generated object field, set to a Jsonnet expression.
+type field struct {
+	key        string
+	expression string
+}
+
+type generator struct {
+	// libraries - The `spin-lib-jsonnet` libraries used by the generated code.
+	libraries map[string]bool
+}
+
+// object - Generates an object, extending `c` (when set), `fields` replace the values of their keys.
+//
+// The constructor defaults the object doesn't set are hidden (I.E. `waitTime:: null`), so the object renders as-is.
+func (g *generator) object(path string, value map[string]interface{}, c *constructor, fields []field) string {
+	expressions := make(map[string]string, len(value)+len(fields))
+	hidden := make(map[string]bool)
+
+	for key, v := range value {
+		if c != nil {
+			if defaultValue, exists := c.defaults[key]; exists && reflect.DeepEqual(defaultValue, v) {
+				continue
+			}
+		}
+
+		expressions[key] = g.value(path+"."+key, v)
+	}
+
+	if c != nil {
+		for key := range c.defaults {
+			if _, exists := value[key]; !exists {
+				expressions[key] = "null"
+				hidden[key] = true
+			}
+		}
+	}
+
+	for _, f := range fields {
+		expressions[f.key] = f.expression
+		delete(hidden, f.key)
+	}
+
+	var lines []string
+
+	for _, key := range orderedKeys(expressions) {
+		keyJSON, _ := generatorJSON.MarshalToString(key)
+		separator := ":"
+
+		if hidden[key] {
+			separator = "::"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %s,", keyJSON, separator, expressions[key]))
+	}
+
+	prefix := ""
+
+	if c != nil {
+		g.libraries[c.library] = true
+		prefix = fmt.Sprintf("%s.%s ", c.library, c.name)
+	}
+
+	if len(lines) == 0 {
+		return prefix + "{}"
+	}
+
+	return fmt.Sprintf("%s{\n%s\n}", prefix, strings.Join(lines, "\n"))
+}
+
+// value - Generates a value, the elements of the pipeline's `stages` & `triggers` use the matching constructors.
+func (g *generator) value(path string, value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return g.object(path, v, nil, nil)
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]"
+		}
+
+		elements := make([]string, 0, len(v))
+
+		for _, element := range v {
+			elementMap, isMap := element.(map[string]interface{})
+
+			switch {
+			case isMap && path == ".stages":
+				elements = append(elements, g.object(path, elementMap, findConstructor(stageConstructors, elementMap), nil)+",")
+			case isMap && path == ".triggers":
+				elements = append(elements, g.object(path, elementMap, findConstructor(triggerConstructors, elementMap), nil)+",")
+			default:
+				elements = append(elements, g.value(path+"[]", element)+",")
+			}
+		}
+
+		return fmt.Sprintf("[\n%s\n]", strings.Join(elements, "\n"))
+	}
+
+	valueJSON, _ := generatorJSON.MarshalToString(value)
+
+	return valueJSON
+}
+
+// findConstructor - The constructor of the object's type, `nil` when the type isn't recognized or required keys are missing.
+func findConstructor(constructors map[string]constructor, value map[string]interface{}) *constructor {
+	objectType, _ := value["type"].(string)
+	c, exists := constructors[objectType]
+
+	if !exists {
+		return nil
+	}
+
+	for _, key := range c.required {
+		if s, isString := value[key].(string); !isString || s == "" {
+			return nil
+		}
+	}
+
+	return &c
+}
+
+func orderedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+
+	for _, key := range leadingKeys {
+		if _, exists := values[key]; exists {
+			keys = append(keys, key)
+		}
+	}
+
+	var rest []string
+
+	for key := range values {
+		if !containsKey(leadingKeys, key) {
+			rest = append(rest, key)
+		}
+	}
+
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withDefaults(base, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(defaults))
+
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range defaults {
+		merged[key] = value
+	}
+
+	return merged
+}
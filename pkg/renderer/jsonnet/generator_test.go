@@ -0,0 +1,122 @@
+package jsonnet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/Autodesk/shore/pkg/renderer/jsonnet"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const importedPipeline = `
+{
+	"application": "app",
+	"name": "deploy",
+	"keepWaitingPipelines": false,
+	"description": "Deploys <everything>",
+	"triggers": [
+		{"type": "jenkins", "master": "ci", "job": "build", "enabled": true, "expectedArtifactIds": [], "propertyFile": ""},
+		{"type": "cron", "cronExpression": "0 0 * * *", "enabled": true}
+	],
+	"stages": [
+		{"refId": "1", "requisiteStageRefIds": [], "type": "wait", "name": "Wait", "waitTime": 30},
+		{"refId": "2", "requisiteStageRefIds": ["1"], "type": "pipeline", "name": "Run child", "application": "other", "pipeline": "child", "waitForCompletion": true, "failPipeline": true, "pipelineParameters": {}},
+		{"refId": "3", "requisiteStageRefIds": ["2"], "type": "deployManifest", "name": "Deploy", "manifests": [{"kind": "Job"}]}
+	]
+}`
+
+func TestGenerateUsesConstructors(t *testing.T) {
+	// Given
+	var pipeline map[string]interface{}
+	jsoniter.UnmarshalFromString(importedPipeline, &pipeline)
+
+	// Test
+	files, err := jsonnet.NewRenderer(afero.NewMemMapFs(), logrus.New()).Generate(pipeline)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	code := files[jsonnet.RenderFiles[renderer.MainFileName]]
+	assert.Contains(t, code, "local stage = import 'spin-lib-jsonnet/stage.libsonnet';")
+	assert.Contains(t, code, "local trigger = import 'spin-lib-jsonnet/trigger.libsonnet';")
+	assert.Contains(t, code, "application: params.application,")
+	assert.Contains(t, code, "name: params.pipeline,")
+	assert.Contains(t, code, "stage.WaitStage {")
+	assert.Contains(t, code, "stage.PipelineStage {")
+	assert.Contains(t, code, "trigger.JenkinsTrigger {")
+	assert.Contains(t, code, "cronExpression: '0 0 * * *',")
+	assert.NotContains(t, code, "waitForCompletion")
+}
+
+// renderGenerated - Generates a project from the pipeline and renders it with the `spin-lib-jsonnet` libraries of the repository.
+func renderGenerated(pipeline map[string]interface{}) (string, error) {
+	fs := afero.NewMemMapFs()
+	libPath := filepath.Join("..", "..", "..", "jsonnet", "libs", jsonnet.SpinLibJsonnet)
+
+	for _, library := range []string{"stage.libsonnet", "trigger.libsonnet"} {
+		content, _ := os.ReadFile(filepath.Join(libPath, library))
+		afero.WriteFile(fs, filepath.Join(testPath, jsonnet.ShareLibsPath, jsonnet.SpinLibJsonnet, library), content, os.ModePerm)
+	}
+
+	afero.WriteFile(fs, filepath.Join(testPath, jsonnet.JsonnetFileName), []byte(`{"version": 1, "dependencies": [], "legacyImports": true}`), os.ModePerm)
+
+	r := jsonnet.NewRenderer(fs, logrus.New())
+	files, err := r.Generate(pipeline)
+
+	if err != nil {
+		return "", err
+	}
+
+	for fileName, content := range files {
+		afero.WriteFile(fs, filepath.Join(testPath, fileName), []byte(content), os.ModePerm)
+	}
+
+	return r.Render(testPath, `{"application": "app", "pipeline": "deploy"}`, renderer.MainFileName)
+}
+
+func TestGenerateRendersThePipeline(t *testing.T) {
+	// Given
+	var pipeline map[string]interface{}
+	jsoniter.UnmarshalFromString(importedPipeline, &pipeline)
+
+	// Test
+	rendered, err := renderGenerated(pipeline)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, importedPipeline, rendered)
+}
+
+func TestGenerateRendersThePipelineWithoutConstructorDefaults(t *testing.T) {
+	// Given
+	pipelineJSON := `
+{
+	"application": "app",
+	"name": "deploy",
+	"triggers": [
+		{"type": "jenkins", "master": "ci", "job": "build", "enabled": false},
+		{"type": "pipeline", "application": "app", "pipeline": "build"}
+	],
+	"stages": [
+		{"refId": "1", "type": "wait", "name": "Wait"},
+		{"refId": "2", "requisiteStageRefIds": ["1"], "type": "pipeline", "name": "Run child", "application": "other", "pipeline": "child"},
+		{"type": "manualJudgment", "name": "Judge"}
+	]
+}`
+
+	var pipeline map[string]interface{}
+	jsoniter.UnmarshalFromString(pipelineJSON, &pipeline)
+
+	// Test
+	rendered, err := renderGenerated(pipeline)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, pipelineJSON, rendered)
+}
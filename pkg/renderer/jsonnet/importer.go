@@ -6,6 +6,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/go-jsonnet"
 	jbV1 "github.com/jsonnet-bundler/jsonnet-bundler/spec/v1"
@@ -16,7 +17,11 @@ type FileImporter struct {
 	JPaths      []string
 	fs          afero.Fs
 	projectPath string
-	fsCache     map[string]*fsCacheEntry
+	fsCache     *importCache
+	// importErr - The last import that couldn't be resolved, used to report friendly render errors.
+	importErr *SharedLibErr
+	// importedBy - The file that first imported each file, used to report the import chain of render errors.
+	importedBy map[string]string
 }
 
 type fsCacheEntry struct {
@@ -24,12 +29,44 @@ type fsCacheEntry struct {
 	contents jsonnet.Contents
 }
 
+// importCache - A cache of the files read by importers, safe for concurrent use.
+// The cache can be shared between importers, so files are only read once across renders.
+type importCache struct {
+	mu      sync.RWMutex
+	entries map[string]*fsCacheEntry
+}
+
+func newImportCache() *importCache {
+	return &importCache{entries: make(map[string]*fsCacheEntry)}
+}
+
+func (c *importCache) get(absPath string) (*fsCacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, isCached := c.entries[absPath]
+	return entry, isCached
+}
+
+func (c *importCache) set(absPath string, entry *fsCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[absPath] = entry
+}
+
 // NewImporter - Get the Jsonnet File Import customized to the Jsonnet Bundler type.
 func NewImporter(fs afero.Fs, projectPath string, jbFile jbV1.JsonnetFile) *FileImporter {
+	return newCachedImporter(fs, projectPath, jbFile, newImportCache())
+}
+
+// newCachedImporter - Same as `NewImporter`, reading files through a shared cache.
+func newCachedImporter(fs afero.Fs, projectPath string, jbFile jbV1.JsonnetFile, cache *importCache) *FileImporter {
 	libsPath := []string{}
 	fileImporter := FileImporter{
 		fs:          fs,
 		projectPath: projectPath,
+		fsCache:     cache,
 	}
 
 	libsPath = append(libsPath, projectPath)
@@ -66,7 +103,7 @@ func NewImporter(fs afero.Fs, projectPath string, jbFile jbV1.JsonnetFile) *File
 
 func (importer *FileImporter) tryPath(dir, importedPath string) (found bool, contents jsonnet.Contents, foundHere string, err error) {
 	if importer.fsCache == nil {
-		importer.fsCache = make(map[string]*fsCacheEntry)
+		importer.fsCache = newImportCache()
 	}
 	var absPath string
 	if path.IsAbs(importedPath) {
@@ -75,7 +112,7 @@ func (importer *FileImporter) tryPath(dir, importedPath string) (found bool, con
 		absPath = path.Join(dir, importedPath)
 	}
 	var entry *fsCacheEntry
-	if cacheEntry, isCached := importer.fsCache[absPath]; isCached {
+	if cacheEntry, isCached := importer.fsCache.get(absPath); isCached {
 		entry = cacheEntry
 	} else {
 		contentBytes, err := afero.ReadFile(importer.fs, absPath)
@@ -93,7 +130,7 @@ func (importer *FileImporter) tryPath(dir, importedPath string) (found bool, con
 				contents: jsonnet.MakeContents(string(contentBytes)),
 			}
 		}
-		importer.fsCache[absPath] = entry
+		importer.fsCache.set(absPath, entry)
 	}
 	return entry.exists, entry.contents, absPath, nil
 }
@@ -119,7 +156,34 @@ func (importer *FileImporter) Import(importedFrom, importedPath string) (content
 	}
 
 	if !found {
-		return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %#v: no match locally or in the Jsonnet library paths", importedPath)
+		importer.importErr = &SharedLibErr{
+			Require: importedPath,
+			Path:    importedFrom,
+			Err:     fmt.Errorf("couldn't open import %#v: no match locally or in the Jsonnet library paths", importedPath),
+		}
+
+		return jsonnet.Contents{}, "", importer.importErr
+	}
+	if importer.importedBy == nil {
+		importer.importedBy = make(map[string]string)
 	}
+
+	if _, exists := importer.importedBy[foundHere]; !exists {
+		importer.importedBy[foundHere] = importedFrom
+	}
+
 	return content, foundHere, nil
 }
+
+// importChain - The files leading to the import of a file, starting with the main file.
+func (importer *FileImporter) importChain(file string) []string {
+	chain := []string{file}
+	seen := map[string]bool{file: true}
+
+	for importedFrom := importer.importedBy[file]; importedFrom != "" && !seen[importedFrom]; importedFrom = importer.importedBy[importedFrom] {
+		seen[importedFrom] = true
+		chain = append([]string{importedFrom}, chain...)
+	}
+
+	return chain
+}
@@ -0,0 +1,97 @@
+package jsonnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Autodesk/shore/pkg/renderer"
+	"github.com/google/go-jsonnet"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/afero"
+)
+
+// RenderConfig - Jsonnet inputs provided through the reserved keys of the render arguments.
+type RenderConfig struct {
+	ExtVars  map[string]string `json:"extVars"`
+	ExtCode  map[string]string `json:"extCode"`
+	EnvVars  []string          `json:"envVars"`
+	TLAFiles map[string]string `json:"tlaFiles"`
+}
+
+var reservedKeys = []string{renderer.ExtVarsKey, renderer.ExtCodeKey, renderer.EnvVarsKey, renderer.TLAFilesKey}
+
+// splitRenderArgs - Separates the renderer configuration from the `params` passed to the rendered code.
+//
+// The render arguments are returned untouched when they don't contain any of the reserved keys.
+func splitRenderArgs(renderArgs string) (string, RenderConfig, error) {
+	var config RenderConfig
+	var args map[string]interface{}
+
+	// Non-object arguments (or empty arguments) can't hold a configuration.
+	if err := jsoniter.UnmarshalFromString(renderArgs, &args); err != nil {
+		return renderArgs, config, nil
+	}
+
+	configArgs := make(map[string]interface{})
+
+	for _, key := range reservedKeys {
+		if value, exists := args[key]; exists {
+			configArgs[key] = value
+			delete(args, key)
+		}
+	}
+
+	if len(configArgs) == 0 {
+		return renderArgs, config, nil
+	}
+
+	configBytes, err := jsoniter.Marshal(configArgs)
+	if err != nil {
+		return "", config, err
+	}
+
+	if err := jsoniter.Unmarshal(configBytes, &config); err != nil {
+		return "", config, fmt.Errorf("invalid renderer configuration in the render arguments: %w", err)
+	}
+
+	params, err := jsoniter.MarshalToString(args)
+	if err != nil {
+		return "", config, err
+	}
+
+	return params, config, nil
+}
+
+// apply - Configures the VM with the external variables and top level arguments of the configuration.
+func (c RenderConfig) apply(vm *jsonnet.VM, fs afero.Fs, projectPath string) error {
+	for key, value := range c.ExtVars {
+		vm.ExtVar(key, value)
+	}
+
+	for key, code := range c.ExtCode {
+		vm.ExtCode(key, code)
+	}
+
+	// Only allowlisted environment variables are exposed, unset variables are skipped.
+	for _, name := range c.EnvVars {
+		if value, exists := os.LookupEnv(name); exists {
+			vm.ExtVar(name, value)
+		}
+	}
+
+	for key, filePath := range c.TLAFiles {
+		if !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(projectPath, filePath)
+		}
+
+		contents, err := afero.ReadFile(fs, filePath)
+		if err != nil {
+			return fmt.Errorf("could not read the top level argument file %q for %q: %w", filePath, key, err)
+		}
+
+		vm.TLAVar(key, string(contents))
+	}
+
+	return nil
+}
@@ -1,8 +1,11 @@
 package jsonnet
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/Autodesk/shore/pkg/renderer"
 	"github.com/google/go-jsonnet"
@@ -30,20 +33,22 @@ const JsonnetFileName string = "jsonnetfile.json"
 
 // Jsonnet - A Jsonnet renderer instance.
 // The struct  holds the required parameters to render a standard shore pipeline.
+//
+// The renderer is safe for concurrent use, each render uses its own VM.
+// Imported files are cached for the lifetime of the renderer and shared between renders.
 type Jsonnet struct {
 	renderer.Renderer
-	vm           *jsonnet.VM
-	fs           afero.Fs
-	log          logrus.FieldLogger
-	fileImporter jsonnet.Importer
+	fs          afero.Fs
+	log         logrus.FieldLogger
+	importCache *importCache
 }
 
 // NewRenderer - Create new instance of the JSONNET renderer.
 func NewRenderer(fs afero.Fs, logger logrus.FieldLogger) *Jsonnet {
-	return &Jsonnet{vm: jsonnet.MakeVM(), fs: fs, log: logger}
+	return &Jsonnet{fs: fs, log: logger, importCache: newImportCache()}
 }
 
-// Render - Render the code with the VM.
+// Render - Render the code with a fresh VM.
 func (j *Jsonnet) Render(projectPath string, renderArgs string, renderType renderer.RenderType) (string, error) {
 	renderFile := filepath.Join(projectPath, RenderFiles[renderType])
 
@@ -54,11 +59,39 @@ func (j *Jsonnet) Render(projectPath string, renderArgs string, renderType rende
 		return "", err
 	}
 
+	if err == nil {
+		j.warnOutdatedDependencies(projectPath, jbFile)
+	}
+
+	params, renderConfig, err := splitRenderArgs(renderArgs)
+
+	if err != nil {
+		return "", err
+	}
+
+	vm := jsonnet.MakeVM()
+	formatter := newErrorFormatter(vm)
+	vm.ErrorFormatter = formatter
+
+	if err := renderConfig.apply(vm, j.fs, projectPath); err != nil {
+		return "", err
+	}
+
 	// Always include params, even if they are empty
-	j.vm.TLACode("params", renderArgs)
-	j.vm.Importer(NewImporter(j.fs, projectPath, jbFile))
+	vm.TLACode("params", params)
+	importer := newCachedImporter(j.fs, projectPath, jbFile, j.importCache)
+	vm.Importer(importer)
+
+	pipelineJSON, err := vm.EvaluateFile(renderFile)
+
+	if err != nil && formatter.err != nil {
+		renderErr := newRenderError(projectPath, formatter.err, importer)
+		renderErr.Hints = j.hints(projectPath, jbFile, renderErr, importer.importErr)
 
-	return j.vm.EvaluateFile(renderFile)
+		return "", renderErr
+	}
+
+	return pipelineJSON, err
 }
 
 // A compliant wrapper implementing jsonnetfile.Load but using `Afero` instrad of `ioutil`.
@@ -71,3 +104,55 @@ func (j *Jsonnet) loadJsonnetBundlerFile(path string) (jbV1.JsonnetFile, error)
 
 	return jsonnetfile.Unmarshal(bytes)
 }
+
+// hints - Suggestions for common mistakes, based on the render error.
+func (j *Jsonnet) hints(projectPath string, jbFile jbV1.JsonnetFile, renderErr *RenderError, importErr *SharedLibErr) []string {
+	var hints []string
+
+	// Only imports made by the pipeline code may be shared libraries (the main file is imported with an empty `importedFrom`).
+	if importErr != nil && importErr.Path != "" && !strings.HasPrefix(importErr.Require, ".") {
+		vendorExists, _ := afero.DirExists(j.fs, filepath.Join(projectPath, ShareLibsPath))
+
+		switch {
+		case len(jbFile.Dependencies) > 0 && !vendorExists:
+			hints = append(hints, fmt.Sprintf("the shared libraries are not installed, run `shore deps install` in %q", projectPath))
+		case !isProvidedByDependency(jbFile, importErr.Require):
+			hints = append(hints, fmt.Sprintf("%q is not provided by any library in %s, add the library with `jb install <repository>`", importErr.Require, JsonnetFileName))
+		default:
+			hints = append(hints, "the shared libraries may be out of date, run `shore deps install` to update the vendored libraries")
+		}
+	}
+
+	if strings.Contains(renderErr.Message, "is a required property") {
+		hints = append(hints, "a required property is missing, make sure it is set in the render arguments (I.E. `render.yml`)")
+	}
+
+	return hints
+}
+
+// isProvidedByDependency - Checks if an import path belongs to one of the Jsonnet-Bundler dependencies.
+func isProvidedByDependency(jbFile jbV1.JsonnetFile, importPath string) bool {
+	for _, dependency := range jbFile.Dependencies {
+		for _, name := range []string{dependency.Name(), dependency.LegacyName(), path.Base(dependency.Name())} {
+			if name != "" && strings.HasPrefix(importPath, name+"/") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// warnOutdatedDependencies - Warns when the lock file doesn't match `jsonnetfile.json` (I.E. a library was added without installing it).
+func (j *Jsonnet) warnOutdatedDependencies(projectPath string, jbFile jbV1.JsonnetFile) {
+	outdated, err := j.outdatedDependencies(projectPath, jbFile)
+
+	if err != nil {
+		j.log.Warn("could not check the dependencies lock file: ", err)
+		return
+	}
+
+	if len(outdated) > 0 {
+		j.log.Warnf("%s is out of date (%s not installed), run `shore deps install`", JsonnetLockFileName, strings.Join(outdated, ", "))
+	}
+}
@@ -1,16 +1,20 @@
 package jsonnet_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/Autodesk/shore/pkg/renderer"
 	"github.com/Autodesk/shore/pkg/renderer/jsonnet"
 	"github.com/jsonnet-bundler/jsonnet-bundler/pkg/jsonnetfile"
 	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -139,3 +143,497 @@ func TestFileImporterLegacySuccess(t *testing.T) {
 	assert.Len(t, importer.JPaths, 2)
 	assert.Equal(t, value, importer.JPaths)
 }
+
+func TestRenderWithExtVarsAndExtCode(t *testing.T) {
+	// Given
+	codeFile := `
+function(params={})(
+	{
+		"region": std.extVar("region"),
+		"replicas": std.extVar("replicas"),
+		"params": params,
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	args := `{"application": "app", "extVars": {"region": "us-east-1"}, "extCode": {"replicas": "1 + 2"}}`
+
+	// Test
+	res, renderErr := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, args, renderer.MainFileName)
+
+	// Assert
+	assert.Nil(t, renderErr)
+	assert.JSONEq(t, `{"region": "us-east-1", "replicas": 3, "params": {"application": "app"}}`, res)
+}
+
+func TestRenderWithEnvVarsAllowlist(t *testing.T) {
+	// Given
+	t.Setenv("SHORE_TEST_ALLOWED", "allowed")
+	t.Setenv("SHORE_TEST_NOT_ALLOWED", "not-allowed")
+
+	codeFile := `
+function(params={})(
+	{
+		"allowed": std.extVar("SHORE_TEST_ALLOWED"),
+	}
+)
+`
+	notAllowedCodeFile := `
+function(params={})(
+	{
+		"notAllowed": std.extVar("SHORE_TEST_NOT_ALLOWED"),
+	}
+)
+`
+	args := `{"envVars": ["SHORE_TEST_ALLOWED"]}`
+
+	// Test
+	res, renderErr := jsonnet.NewRenderer(SetupRenderWithArgs("json", codeFile, ""), logrus.New()).Render(testPath, args, renderer.MainFileName)
+	_, notAllowedErr := jsonnet.NewRenderer(SetupRenderWithArgs("json", notAllowedCodeFile, ""), logrus.New()).Render(testPath, args, renderer.MainFileName)
+
+	// Assert
+	assert.Nil(t, renderErr)
+	assert.JSONEq(t, `{"allowed": "allowed"}`, res)
+	assert.ErrorContains(t, notAllowedErr, "Undefined external variable: SHORE_TEST_NOT_ALLOWED")
+}
+
+func TestRenderWithTLAFiles(t *testing.T) {
+	// Given
+	codeFile := `
+function(params={}, script="")(
+	{
+		"script": script,
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, "scripts", "run.sh"), []byte("echo hello"), os.ModePerm)
+	args := `{"tlaFiles": {"script": "scripts/run.sh"}}`
+
+	// Test
+	res, renderErr := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, args, renderer.MainFileName)
+
+	// Assert
+	assert.Nil(t, renderErr)
+	assert.JSONEq(t, `{"script": "echo hello"}`, res)
+}
+
+func TestRenderWithMissingTLAFileFails(t *testing.T) {
+	// Given
+	codeFile := `
+function(params={}, script="")(
+	{
+		"script": script,
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	args := `{"tlaFiles": {"script": "scripts/missing.sh"}}`
+
+	// Test
+	_, renderErr := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, args, renderer.MainFileName)
+
+	// Assert
+	assert.ErrorContains(t, renderErr, `could not read the top level argument file "/tmp/test/scripts/missing.sh" for "script"`)
+}
+
+func TestRenderDoesNotLeakInputsBetweenRenders(t *testing.T) {
+	// Given
+	codeFile := `
+function(params={})(
+	{
+		"region": std.extVar("region"),
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	jsonnetRenderer := jsonnet.NewRenderer(fs, logrus.New())
+
+	// Test
+	_, firstErr := jsonnetRenderer.Render(testPath, `{"extVars": {"region": "us-east-1"}}`, renderer.MainFileName)
+	_, secondErr := jsonnetRenderer.Render(testPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	assert.Nil(t, firstErr)
+	assert.ErrorContains(t, secondErr, "Undefined external variable: region")
+}
+
+func TestConcurrentRenders(t *testing.T) {
+	// Given
+	codeFile := `
+local lib = import "lib.libsonnet";
+
+function(params={})(
+	{
+		"name": lib.name(params.index),
+		"region": std.extVar("region"),
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, "lib.libsonnet"), []byte(`{ name(index):: "pipeline-%d" % index }`), os.ModePerm)
+	jsonnetRenderer := jsonnet.NewRenderer(fs, logrus.New())
+
+	const renders = 20
+	results := make([]string, renders)
+	errs := make([]error, renders)
+
+	// Test
+	var wg sync.WaitGroup
+
+	for i := 0; i < renders; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			args := fmt.Sprintf(`{"index": %d, "extVars": {"region": "region-%d"}}`, i, i)
+			results[i], errs[i] = jsonnetRenderer.Render(testPath, args, renderer.MainFileName)
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Assert
+	for i := 0; i < renders; i++ {
+		assert.Nil(t, errs[i])
+		assert.JSONEq(t, fmt.Sprintf(`{"name": "pipeline-%d", "region": "region-%d"}`, i, i), results[i])
+	}
+}
+
+func TestRenderErrorWithImportChain(t *testing.T) {
+	// Given
+	codeFile := `
+local stages = import "lib/stages.libsonnet";
+
+function(params={})(
+	{
+		"stages": stages.wait(params),
+	}
+)
+`
+	libFile := `{
+  wait(params):: [
+    { type: "wait", waitTime: params.waitTime },
+  ],
+}
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, "lib", "stages.libsonnet"), []byte(libFile), os.ModePerm)
+
+	// Test
+	_, err := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	var renderErr *jsonnet.RenderError
+
+	assert.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, "Field does not exist: waitTime", renderErr.Message)
+	assert.Equal(t, "lib/stages.libsonnet", renderErr.File)
+	assert.Equal(t, 3, renderErr.Line)
+	assert.Equal(t, 31, renderErr.Column)
+	assert.Equal(t, []string{"main.pipeline.jsonnet", "lib/stages.libsonnet"}, renderErr.ImportChain)
+	assert.Equal(t, "3 |     { type: \"wait\", waitTime: params.waitTime },\n  |                               ^^^^^^^^^^^^^^^\n", renderErr.Snippet)
+}
+
+func TestRenderErrorMissingVendorHint(t *testing.T) {
+	// Given
+	codeFile := `
+local lib = import "sharedlib1/lib.libsonnet";
+
+function(params={})(lib)
+`
+	jbFile := `{
+	"version": 1,
+	"dependencies": [
+		{"source": {"git": {"remote": "https://github.com/org-1/sharedlib1.git", "subdir": ""}}, "version": "master"}
+	],
+	"legacyImports": false
+}`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, jsonnet.JsonnetFileName), []byte(jbFile), os.ModePerm)
+
+	// Test
+	_, err := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	var renderErr *jsonnet.RenderError
+	var sharedLibErr jsonnet.SharedLibErr
+
+	assert.True(t, errors.As(err, &renderErr))
+	assert.True(t, errors.As(err, &sharedLibErr))
+	assert.Equal(t, "sharedlib1/lib.libsonnet", sharedLibErr.Require)
+	assert.Equal(t, "main.pipeline.jsonnet", renderErr.File)
+	assert.Len(t, renderErr.Hints, 1)
+	assert.Contains(t, renderErr.Hints[0], "run `shore deps install`")
+}
+
+func TestRenderErrorLibraryMissingFromJsonnetFileHint(t *testing.T) {
+	// Given
+	codeFile := `
+local lib = import "sharedlib2/lib.libsonnet";
+
+function(params={})(lib)
+`
+	jbFile := `{
+	"version": 1,
+	"dependencies": [
+		{"source": {"git": {"remote": "https://github.com/org-1/sharedlib1.git", "subdir": ""}}, "version": "master"}
+	],
+	"legacyImports": false
+}`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, jsonnet.JsonnetFileName), []byte(jbFile), os.ModePerm)
+	fs.MkdirAll(filepath.Join(testPath, jsonnet.ShareLibsPath, "github.com", "org-1", "sharedlib1"), os.ModePerm)
+
+	// Test
+	_, err := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	var renderErr *jsonnet.RenderError
+
+	assert.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, []string{`"sharedlib2/lib.libsonnet" is not provided by any library in jsonnetfile.json, add the library with ` + "`jb install <repository>`"}, renderErr.Hints)
+}
+
+func TestRenderErrorRequiredPropertyHint(t *testing.T) {
+	// Given
+	codeFile := `
+function(params={})(
+	{
+		"application": if std.objectHas(params, "application") then params.application else error "application is a required property",
+	}
+)
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+
+	// Test
+	_, err := jsonnet.NewRenderer(fs, logrus.New()).Render(testPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	var renderErr *jsonnet.RenderError
+
+	assert.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, "application is a required property", renderErr.Message)
+	assert.Len(t, renderErr.Hints, 1)
+	assert.Contains(t, err.Error(), "Hint: a required property is missing")
+}
+
+func TestFormatCheck(t *testing.T) {
+	// Given
+	unformatted := "function(params={})(\n{\"a\":   \"b\"}\n)\n"
+	fs := SetupRenderWithArgs("json", unformatted, "")
+	afero.WriteFile(fs, filepath.Join(testPath, "lib", "formatted.libsonnet"), []byte("{ a: 'b' }\n"), os.ModePerm)
+	afero.WriteFile(fs, filepath.Join(testPath, jsonnet.ShareLibsPath, "lib", "unformatted.libsonnet"), []byte("{\"a\":   \"b\"}"), os.ModePerm)
+
+	// Test
+	files, err := jsonnet.NewRenderer(fs, logrus.New()).Format(testPath, false)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{jsonnet.RenderFiles[renderer.MainFileName]}, files)
+
+	contents, _ := afero.ReadFile(fs, filepath.Join(testPath, jsonnet.RenderFiles[renderer.MainFileName]))
+	assert.Equal(t, unformatted, string(contents))
+}
+
+func TestFormatWrite(t *testing.T) {
+	// Given
+	fs := SetupRenderWithArgs("json", "function(params={})(\n{\"a\":   \"b\"}\n)\n", "")
+	jsonnetRenderer := jsonnet.NewRenderer(fs, logrus.New())
+
+	// Test
+	files, err := jsonnetRenderer.Format(testPath, true)
+	filesAfterWrite, checkErr := jsonnetRenderer.Format(testPath, false)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, checkErr)
+	assert.Equal(t, []string{jsonnet.RenderFiles[renderer.MainFileName]}, files)
+	assert.Empty(t, filesAfterWrite)
+
+	contents, _ := afero.ReadFile(fs, filepath.Join(testPath, jsonnet.RenderFiles[renderer.MainFileName]))
+	assert.Equal(t, "function(params={}) (\n  { a: 'b' }\n)\n", string(contents))
+}
+
+func TestLint(t *testing.T) {
+	// Given
+	codeFile := `
+local lib = import "lib/lib.libsonnet";
+local unused = "value";
+
+function(params={}) lib
+`
+	fs := SetupRenderWithArgs("json", codeFile, "")
+	afero.WriteFile(fs, filepath.Join(testPath, "lib", "lib.libsonnet"), []byte("{ a: 'b' }\n"), os.ModePerm)
+
+	var output bytes.Buffer
+
+	// Test
+	issuesFound, err := jsonnet.NewRenderer(fs, logrus.New()).Lint(testPath, &output)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, issuesFound)
+	assert.Contains(t, output.String(), "main.pipeline.jsonnet:3:7-23 Unused variable: unused")
+	assert.NotContains(t, output.String(), testPath)
+}
+
+func TestLintNoIssues(t *testing.T) {
+	// Given
+	fs := SetupRenderWithArgs("json", "function(params={}) { a: params }\n", "")
+
+	var output bytes.Buffer
+
+	// Test
+	issuesFound, err := jsonnet.NewRenderer(fs, logrus.New()).Lint(testPath, &output)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.False(t, issuesFound)
+	assert.Empty(t, output.String())
+}
+
+func setupLocalDependencyProject(t *testing.T) (afero.Fs, string) {
+	projectPath := t.TempDir()
+	fs := afero.NewOsFs()
+
+	jbFile := `{
+	"version": 1,
+	"dependencies": [
+		{"source": {"local": {"directory": "libs/mylib"}}, "version": ""}
+	],
+	"legacyImports": false
+}`
+	codeFile := `
+local lib = import "mylib/lib.libsonnet";
+
+function(params={}) lib
+`
+
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.JsonnetFileName), []byte(jbFile), os.ModePerm)
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.RenderFiles[renderer.MainFileName]), []byte(codeFile), os.ModePerm)
+	fs.MkdirAll(filepath.Join(projectPath, "libs", "mylib"), os.ModePerm)
+	afero.WriteFile(fs, filepath.Join(projectPath, "libs", "mylib", "lib.libsonnet"), []byte(`{ from: "mylib" }`), os.ModePerm)
+
+	return fs, projectPath
+}
+
+func TestInstallLocalDependencies(t *testing.T) {
+	// Given
+	fs, projectPath := setupLocalDependencyProject(t)
+	jsonnetRenderer := jsonnet.NewRenderer(fs, logrus.New())
+
+	// Test
+	err := jsonnetRenderer.InstallDependencies(projectPath)
+	res, renderErr := jsonnetRenderer.Render(projectPath, `{}`, renderer.MainFileName)
+	dependencies, listErr := jsonnetRenderer.ListDependencies(projectPath)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, renderErr)
+	assert.Nil(t, listErr)
+	assert.JSONEq(t, `{"from": "mylib"}`, res)
+
+	lockExists, _ := afero.Exists(fs, filepath.Join(projectPath, jsonnet.JsonnetLockFileName))
+	assert.True(t, lockExists)
+	assert.Equal(t, []renderer.Dependency{{Name: "mylib", Version: "", LockedVersion: "libs/mylib"}}, dependencies)
+}
+
+func TestListDependenciesNotInstalled(t *testing.T) {
+	// Given
+	fs, projectPath := setupLocalDependencyProject(t)
+
+	// Test
+	dependencies, err := jsonnet.NewRenderer(fs, logrus.New()).ListDependencies(projectPath)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []renderer.Dependency{{Name: "mylib"}}, dependencies)
+}
+
+func TestRenderWarnsOnOutdatedLockFile(t *testing.T) {
+	// Given
+	fs, projectPath := setupLocalDependencyProject(t)
+	logger, hook := test.NewNullLogger()
+
+	// Test
+	jsonnet.NewRenderer(fs, logger).Render(projectPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	assert.NotNil(t, hook.LastEntry())
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Equal(t, "jsonnetfile.lock.json is out of date (mylib not installed), run `shore deps install`", hook.LastEntry().Message)
+}
+
+func TestInstallLocalDependenciesKeepsWorkingDirectory(t *testing.T) {
+	// Given
+	fs, projectPath := setupLocalDependencyProject(t)
+	workingDirectory, _ := os.Getwd()
+
+	// Test
+	err := jsonnet.NewRenderer(fs, logrus.New()).InstallDependencies(projectPath)
+
+	// Assert
+	currentDirectory, _ := os.Getwd()
+	lockFile, _ := afero.ReadFile(fs, filepath.Join(projectPath, jsonnet.JsonnetLockFileName))
+
+	assert.Nil(t, err)
+	assert.Equal(t, workingDirectory, currentDirectory)
+	assert.Contains(t, string(lockFile), `"directory":"libs/mylib"`)
+}
+
+func TestRenderWarnsOnChangedDependencyVersion(t *testing.T) {
+	// Given
+	fs := afero.NewMemMapFs()
+	projectPath := "/test"
+	logger, hook := test.NewNullLogger()
+
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.JsonnetFileName), []byte(`{
+	"version": 1,
+	"dependencies": [
+		{"source": {"git": {"remote": "https://github.com/example/lib.git", "subdir": ""}}, "version": "1111111"},
+		{"source": {"git": {"remote": "https://github.com/example/branch.git", "subdir": ""}}, "version": "main"}
+	]
+}`), os.ModePerm)
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.JsonnetLockFileName), []byte(`{
+	"version": 1,
+	"dependencies": [
+		{"source": {"git": {"remote": "https://github.com/example/lib.git", "subdir": ""}}, "version": "2222222222222222222222222222222222222222", "sum": ""},
+		{"source": {"git": {"remote": "https://github.com/example/branch.git", "subdir": ""}}, "version": "3333333333333333333333333333333333333333", "sum": ""}
+	]
+}`), os.ModePerm)
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.RenderFiles[renderer.MainFileName]), []byte(`function(params={}) {}`), os.ModePerm)
+
+	// Test
+	jsonnet.NewRenderer(fs, logger).Render(projectPath, `{}`, renderer.MainFileName)
+
+	// Assert
+	assert.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "jsonnetfile.lock.json is out of date (github.com/example/lib not installed), run `shore deps install`", hook.LastEntry().Message)
+}
+
+func TestInstallDependenciesUsesRendererFilesystem(t *testing.T) {
+	// Given
+	fs := afero.NewMemMapFs()
+	projectPath := t.TempDir()
+	jsonnetRenderer := jsonnet.NewRenderer(fs, logrus.New())
+
+	afero.WriteFile(fs, filepath.Join(projectPath, jsonnet.JsonnetFileName), []byte(`{"version": 1, "dependencies": []}`), os.ModePerm)
+	// Jsonnet-Bundler only installs into the OS filesystem.
+	os.MkdirAll(filepath.Join(projectPath, jsonnet.ShareLibsPath), os.ModePerm)
+
+	// Test
+	err := jsonnetRenderer.InstallDependencies(projectPath)
+	dependencies, listErr := jsonnetRenderer.ListDependencies(projectPath)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, listErr)
+	assert.Empty(t, dependencies)
+
+	lockExists, _ := afero.Exists(fs, filepath.Join(projectPath, jsonnet.JsonnetLockFileName))
+	_, osErr := os.Stat(filepath.Join(projectPath, jsonnet.JsonnetLockFileName))
+	assert.True(t, lockExists)
+	assert.True(t, os.IsNotExist(osErr))
+}
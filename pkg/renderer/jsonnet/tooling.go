@@ -0,0 +1,144 @@
+package jsonnet
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/google/go-jsonnet/linter"
+	"github.com/spf13/afero"
+)
+
+// SourceFileExtensions - The extensions of the Jsonnet files in a project.
+var SourceFileExtensions = []string{".jsonnet", ".libsonnet"}
+
+// Format - Formats the project's Jsonnet files with the `go-jsonnet` formatter (the `jsonnetfmt` defaults).
+// Shared libraries (`vendor/`) are never formatted.
+func (j *Jsonnet) Format(projectPath string, write bool) ([]string, error) {
+	files, err := j.sourceFiles(projectPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var unformatted []string
+
+	for _, file := range files {
+		info, err := j.fs.Stat(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contents, err := afero.ReadFile(j.fs, file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		formatted, err := formatter.Format(file, string(contents), formatter.DefaultOptions())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if formatted == string(contents) {
+			continue
+		}
+
+		unformatted = append(unformatted, relativePath(projectPath, file))
+
+		if !write {
+			continue
+		}
+
+		j.log.Debug("Formatting ", file)
+
+		if err := afero.WriteFile(j.fs, file, []byte(formatted), info.Mode()); err != nil {
+			return nil, err
+		}
+	}
+
+	return unformatted, nil
+}
+
+// Lint - Lints the project's Jsonnet files with the `go-jsonnet` linter (I.E. unused variables).
+// Imports are resolved with the same paths used to render the project.
+func (j *Jsonnet) Lint(projectPath string, output io.Writer) (bool, error) {
+	jbFile, err := j.loadJsonnetBundlerFile(projectPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	files, err := j.sourceFiles(projectPath)
+
+	if err != nil {
+		return false, err
+	}
+
+	importer := NewImporter(j.fs, projectPath, jbFile)
+	issuesFound := false
+
+	var report bytes.Buffer
+
+	// Files are linted one at a time, the linter can't handle a file that is both linted and imported by another linted file.
+	for _, file := range files {
+		contents, err := afero.ReadFile(j.fs, file)
+
+		if err != nil {
+			return false, err
+		}
+
+		vm := jsonnet.MakeVM()
+		vm.Importer(importer)
+
+		if linter.LintSnippet(vm, &report, []linter.Snippet{{FileName: file, Code: string(contents)}}) {
+			issuesFound = true
+		}
+	}
+
+	// Keep the report readable, paths are relative to the project.
+	_, err = io.WriteString(output, strings.ReplaceAll(report.String(), projectPath+string(filepath.Separator), ""))
+
+	return issuesFound, err
+}
+
+// sourceFiles - The project's Jsonnet files, excluding the shared libraries.
+func (j *Jsonnet) sourceFiles(projectPath string) ([]string, error) {
+	var files []string
+	vendorPath := filepath.Join(projectPath, ShareLibsPath)
+
+	err := afero.Walk(j.fs, projectPath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path == vendorPath {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		for _, extension := range SourceFileExtensions {
+			if filepath.Ext(path) == extension {
+				files = append(files, path)
+				break
+			}
+		}
+
+		return nil
+	})
+
+	sort.Strings(files)
+
+	return files, err
+}
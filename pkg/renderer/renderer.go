@@ -1,5 +1,10 @@
 package renderer
 
+import (
+	"fmt"
+	"io"
+)
+
 type RenderType int
 
 const (
@@ -7,7 +12,71 @@ const (
 	CleanUpFileName
 )
 
+// String - A renderer agnostic name of the rendered entrypoint, I.E. `main`.
+func (r RenderType) String() string {
+	switch r {
+	case MainFileName:
+		return "main"
+	case CleanUpFileName:
+		return "cleanup"
+	}
+
+	return fmt.Sprintf("RenderType(%d)", int(r))
+}
+
+// Render arguments keys reserved for the renderer configuration.
+// Renderers that support external inputs read them from these keys and do not pass them to the rendered code as parameters.
+const (
+	// ExtVarsKey - external string variables (`map[string]string`).
+	ExtVarsKey = "extVars"
+	// ExtCodeKey - external code variables (`map[string]string`).
+	ExtCodeKey = "extCode"
+	// EnvVarsKey - an allowlist of environment variable names exposed as external string variables (`[]string`).
+	EnvVarsKey = "envVars"
+	// TLAFilesKey - top level arguments read from files relative to the project (`map[string]string`).
+	TLAFilesKey = "tlaFiles"
+)
+
 // Renderer - An instance of a Renderer much take a file and render it's output.
 type Renderer interface {
 	Render(projectPath string, renderArgs string, renderType RenderType) (string, error)
 }
+
+// Formatter - An optional interface for renderers that can format the project's code.
+type Formatter interface {
+	// Format - Returns the project files that are not formatted, the files are rewritten when `write` is set.
+	Format(projectPath string, write bool) ([]string, error)
+}
+
+// Linter - An optional interface for renderers that can lint the project's code.
+type Linter interface {
+	// Lint - Writes the issues found in the project to `output`, returns `true` when issues were found.
+	Lint(projectPath string, output io.Writer) (bool, error)
+}
+
+// Generator - An optional interface for renderers that can generate the code of an existing pipeline (`shore import`).
+type Generator interface {
+	// Generate - Returns the project files (keyed by their path relative to the project) rendering `pipeline`.
+	// The `application` & `name` of the pipeline are read from the render arguments (`application` & `pipeline`).
+	Generate(pipeline map[string]interface{}) (map[string]string, error)
+}
+
+// Dependency - A shared library the project depends on.
+type Dependency struct {
+	Name string
+	// Version - The version requested by the project.
+	Version string
+	// LockedVersion - The version resolved when the dependency was installed, empty when not installed.
+	LockedVersion string
+}
+
+// DependencyManager - An optional interface for renderers that can install the project's shared libraries.
+type DependencyManager interface {
+	// InstallDependencies - Installs the dependencies at their locked versions (resolving the ones that are not locked yet).
+	InstallDependencies(projectPath string) error
+	// UpdateDependencies - Resolves the dependencies again, ignoring their locked versions.
+	// All the dependencies are updated when no names are provided.
+	UpdateDependencies(projectPath string, names []string) error
+	// ListDependencies - Lists the direct dependencies of the project.
+	ListDependencies(projectPath string) ([]Dependency, error)
+}
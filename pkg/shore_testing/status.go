@@ -0,0 +1,146 @@
+package shore_testing
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// StatusMatcher - The expected status of a stage, one of:
+//   - A status - `succeeded`
+//   - A list of acceptable statuses - `[succeeded, skipped]`
+//   - A negated status (or list of statuses) - `{not: terminal}`
+//
+// Statuses are case insensitive, they are normalized to uppercase.
+type StatusMatcher struct {
+	Statuses []string
+	Not      bool
+}
+
+// NewStatusMatcher - Creates a StatusMatcher accepting any of the statuses.
+func NewStatusMatcher(statuses ...string) StatusMatcher {
+	return StatusMatcher{Statuses: normalizeStatuses(statuses)}
+}
+
+// NewNotStatusMatcher - Creates a StatusMatcher accepting any status but the statuses.
+func NewNotStatusMatcher(statuses ...string) StatusMatcher {
+	return StatusMatcher{Statuses: normalizeStatuses(statuses), Not: true}
+}
+
+// IsEmpty - `true` when no status is expected.
+func (m StatusMatcher) IsEmpty() bool {
+	return len(m.Statuses) == 0
+}
+
+// Matches - Whether a status matches the expected status (case insensitive).
+func (m StatusMatcher) Matches(status string) bool {
+	status = strings.ToUpper(status)
+
+	for _, expected := range m.Statuses {
+		if expected == status {
+			return !m.Not
+		}
+	}
+
+	return m.Not
+}
+
+func (m StatusMatcher) String() string {
+	statuses := strings.Join(m.Statuses, ", ")
+
+	if len(m.Statuses) > 1 {
+		statuses = "one of " + statuses
+	}
+
+	if m.Not {
+		return "not " + statuses
+	}
+
+	return statuses
+}
+
+// UnmarshalJSON - Decodes the status, list or `not` forms.
+func (m *StatusMatcher) UnmarshalJSON(data []byte) error {
+	var value interface{}
+
+	if err := jsoniter.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if object, ok := value.(map[string]interface{}); ok {
+		notValue, exists := object["not"]
+
+		if !exists || len(object) != 1 {
+			return fmt.Errorf("expected_status object must only have a `not` key")
+		}
+
+		statuses, err := parseStatuses(notValue)
+
+		if err != nil {
+			return err
+		}
+
+		*m = NewNotStatusMatcher(statuses...)
+		return nil
+	}
+
+	statuses, err := parseStatuses(value)
+
+	if err != nil {
+		return err
+	}
+
+	*m = NewStatusMatcher(statuses...)
+	return nil
+}
+
+// MarshalJSON - Encodes the matcher in its shortest form.
+func (m StatusMatcher) MarshalJSON() ([]byte, error) {
+	var statuses interface{} = m.Statuses
+
+	if len(m.Statuses) == 1 {
+		statuses = m.Statuses[0]
+	}
+
+	if m.Not {
+		return jsoniter.Marshal(map[string]interface{}{"not": statuses})
+	}
+
+	return jsoniter.Marshal(statuses)
+}
+
+func parseStatuses(value interface{}) ([]string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{typedValue}, nil
+	case []interface{}:
+		statuses := make([]string, 0, len(typedValue))
+
+		for _, status := range typedValue {
+			stringStatus, ok := status.(string)
+
+			if !ok {
+				return nil, fmt.Errorf("expected_status must be a list of strings, got %v", typedValue)
+			}
+
+			statuses = append(statuses, stringStatus)
+		}
+
+		return statuses, nil
+	}
+
+	return nil, fmt.Errorf("expected_status must be a status, a list of statuses or an object with a `not` key, got %v", value)
+}
+
+func normalizeStatuses(statuses []string) []string {
+	normalized := make([]string, 0, len(statuses))
+
+	for _, status := range statuses {
+		normalized = append(normalized, strings.ToUpper(strings.TrimSpace(status)))
+	}
+
+	return normalized
+}
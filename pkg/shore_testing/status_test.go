@@ -0,0 +1,60 @@
+package shore_testing
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalStatusMatcher(t *testing.T) {
+	tests := map[string]StatusMatcher{
+		`"succeeded"`:                       NewStatusMatcher("SUCCEEDED"),
+		`["succeeded", "Skipped"]`:          NewStatusMatcher("SUCCEEDED", "SKIPPED"),
+		`{"not": "terminal"}`:               NewNotStatusMatcher("TERMINAL"),
+		`{"not": ["terminal", "canceled"]}`: NewNotStatusMatcher("TERMINAL", "CANCELED"),
+	}
+
+	for data, expected := range tests {
+		// Given
+		var matcher StatusMatcher
+
+		// Test
+		err := jsoniter.Unmarshal([]byte(data), &matcher)
+
+		// Assert
+		assert.Nil(t, err, data)
+		assert.Equal(t, expected, matcher, data)
+	}
+}
+
+func TestUnmarshalStatusMatcherInvalid(t *testing.T) {
+	for _, data := range []string{`1`, `[1]`, `{"is": "succeeded"}`, `{"not": "terminal", "is": "succeeded"}`} {
+		// Given
+		var matcher StatusMatcher
+
+		// Test
+		err := jsoniter.Unmarshal([]byte(data), &matcher)
+
+		// Assert
+		assert.NotNil(t, err, data)
+	}
+}
+
+func TestStatusMatcherMatches(t *testing.T) {
+	assert.True(t, NewStatusMatcher("succeeded").Matches("SUCCEEDED"))
+	assert.True(t, NewStatusMatcher("succeeded", "skipped").Matches("SKIPPED"))
+	assert.False(t, NewStatusMatcher("succeeded", "skipped").Matches("TERMINAL"))
+	assert.True(t, NewNotStatusMatcher("terminal").Matches("SUCCEEDED"))
+	assert.False(t, NewNotStatusMatcher("terminal", "canceled").Matches("CANCELED"))
+}
+
+func TestMarshalStatusMatcher(t *testing.T) {
+	single, _ := jsoniter.Marshal(NewStatusMatcher("succeeded"))
+	list, _ := jsoniter.Marshal(NewStatusMatcher("succeeded", "skipped"))
+	not, _ := jsoniter.Marshal(NewNotStatusMatcher("terminal"))
+
+	assert.Equal(t, `"SUCCEEDED"`, string(single))
+	assert.Equal(t, `["SUCCEEDED","SKIPPED"]`, string(list))
+	assert.Equal(t, `{"not":"TERMINAL"}`, string(not))
+}
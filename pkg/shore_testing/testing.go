@@ -19,6 +19,6 @@ type TestConfig struct {
 
 // Assertion - describes supported stage assertions
 type Assertion struct {
-	ExpectedStatus string                 `json:"expected_status"`
+	ExpectedStatus StatusMatcher          `json:"expected_status"`
 	ExpectedOutput map[string]interface{} `json:"expected_output"`
 }